@@ -0,0 +1,167 @@
+// Command admin manages back office accounts from the shell, e.g. to create
+// the first ADMIN of a fresh database:
+//
+//	go run ./cmd/admin create -email owner@club.vn -role ADMIN
+//	go run ./cmd/admin reset-password -email owner@club.vn
+//	go run ./cmd/admin disable -email owner@club.vn
+//	go run ./cmd/admin enable -email owner@club.vn
+//	go run ./cmd/admin list
+//
+// It reads the same database settings as the server. A temporary password is
+// generated unless -password-stdin is given, and has to be changed at the
+// first sign-in.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"backend-ping-pong-app/internal/config"
+	"backend-ping-pong-app/internal/database"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+	"backend-ping-pong-app/internal/service"
+)
+
+const usage = `usage: admin <command> [flags]
+
+commands:
+  create          -email EMAIL [-role ROLE] [-password-stdin]
+  reset-password  -email EMAIL [-password-stdin]
+  disable         -email EMAIL
+  enable          -email EMAIL
+  list
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "admin:", describe(err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	email := flags.String("email", "", "email of the account")
+	role := flags.String("role", models.AdminRoleAdmin, "role of the account: ADMIN, MODERATOR, CAPTAIN or VIEWER")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin instead of generating one")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	switch command {
+	case "create", "reset-password", "disable", "enable":
+		if *email == "" {
+			return errors.New("-email is required")
+		}
+	case "list":
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	password := ""
+	if *passwordStdin {
+		var err error
+		if password, err = readPassword(os.Stdin); err != nil {
+			return err
+		}
+	}
+
+	cfg := config.Load()
+	db, err := database.OpenPostgresDB(cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	admins := service.NewAdminService(repository.NewRepository(db))
+
+	switch command {
+	case "create":
+		credentials, err := admins.InviteAdminService(ctx, *email, *role, password)
+		if err != nil {
+			return err
+		}
+		printCredentials("created", credentials, *passwordStdin)
+
+	case "reset-password":
+		admin, err := admins.GetAdminByEmailService(ctx, *email)
+		if err != nil {
+			return err
+		}
+		credentials, err := admins.ResetAdminPasswordService(ctx, admin.ID, password)
+		if err != nil {
+			return err
+		}
+		printCredentials("reset", credentials, *passwordStdin)
+
+	case "disable", "enable":
+		admin, err := admins.GetAdminByEmailService(ctx, *email)
+		if err != nil {
+			return err
+		}
+		admin, err = admins.SetAdminActiveService(ctx, admin.ID, command == "enable")
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s %sd\n", admin.Email, command)
+
+	case "list":
+		list, err := admins.GetAdminsService(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "EMAIL\tROLE\tACTIVE\tMUST CHANGE PASSWORD")
+		for _, admin := range list {
+			fmt.Fprintf(w, "%s\t%s\t%t\t%t\n", admin.Email, admin.Role, admin.IsActive, admin.MustChangePassword)
+		}
+		return w.Flush()
+	}
+	return nil
+}
+
+// readPassword reads the first line of r
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("no password on stdin")
+	}
+	return password, nil
+}
+
+func printCredentials(action string, credentials *models.AdminCredentials, ownPassword bool) {
+	fmt.Printf("%s %s (%s)\n", credentials.Admin.Email, action, credentials.Admin.Role)
+	if !ownPassword {
+		fmt.Printf("temporary password: %s\n", credentials.TemporaryPassword)
+	}
+	fmt.Println("the password must be changed at the first sign-in")
+}
+
+// describe adds the details of an AppError, such as the failed password rule
+func describe(err error) string {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) && appErr.Details != nil {
+		return fmt.Sprintf("%s %v", appErr.Error(), appErr.Details)
+	}
+	return err.Error()
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
+
 	"backend-ping-pong-app/internal/config"
 	"backend-ping-pong-app/internal/database"
 	"backend-ping-pong-app/internal/handlers"
+	"backend-ping-pong-app/internal/jobs"
 	"backend-ping-pong-app/internal/middleware"
 	"backend-ping-pong-app/internal/repository"
 	"backend-ping-pong-app/internal/service"
@@ -25,6 +28,12 @@ func main() {
 		cfg.Database.User,
 		cfg.Database.Password != "",
 	)
+	if cfg.Auth.JWTSecret == "" {
+		log.Warn("AUTH_JWT_SECRET is not set, admin tokens will not survive a restart")
+	}
+	if cfg.Auth.FirebaseProjectID == "" {
+		log.Info("FIREBASE_PROJECT_ID is not set, player sign-in is disabled")
+	}
 
 	db, err := database.OpenPostgresDB(cfg.Database)
 	if err != nil {
@@ -33,7 +42,18 @@ func main() {
 	defer db.Close()
 
 	repo := repository.NewRepository(db)
-	svc := service.NewService(repo)
+	svc := service.NewService(repo, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheduler := jobs.NewScheduler(db)
+	scheduler.Register(jobs.NewLeaderboardRefreshJob(db, cfg.Jobs.LeaderboardRefreshInterval))
+	scheduler.Register(jobs.NewRankRecalcJob(svc.Season, svc.Rank, cfg.Jobs.RankRecalcInterval))
+	scheduler.Register(jobs.NewLineupLockJob(svc.Lineup, cfg.Jobs.LineupLockInterval))
+	if cfg.Jobs.Enabled {
+		scheduler.Start(ctx)
+	}
 
 	router := gin.New()
 	router.Use(
@@ -42,7 +62,7 @@ func main() {
 		middleware.CORS(),
 	)
 
-	handlers.RegisterRoutes(router, svc)
+	handlers.RegisterRoutes(router, svc, scheduler)
 
 	log.Info("🚀 Server running on :%s", cfg.App.Port)
 	if err := router.Run(":" + cfg.App.Port); err != nil {
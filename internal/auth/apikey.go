@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Scope is what an API key may do. Keys only reach the routes declared for
+// one of their scopes, public or not.
+type Scope string
+
+const (
+	// ScopeLeaderboardRead covers leaderboards, standings and ranks
+	ScopeLeaderboardRead Scope = "leaderboard:read"
+	// ScopeFixturesRead covers fixtures, lineups, rubbers and brackets
+	ScopeFixturesRead Scope = "fixtures:read"
+	// ScopeResultsWrite covers recording rubber results, e.g. from a
+	// scoreboard device
+	ScopeResultsWrite Scope = "results:write"
+)
+
+// RoleAPIKey is the role of callers authenticated by an API key
+const RoleAPIKey = "API_KEY"
+
+// Scopes lists every API key scope
+var Scopes = []Scope{ScopeLeaderboardRead, ScopeFixturesRead, ScopeResultsWrite}
+
+// IsScope reports whether scope is a known API key scope
+func IsScope(scope string) bool {
+	for _, known := range Scopes {
+		if string(known) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	apiKeyPrefix = "ppk_"
+	// apiKeyDisplayLength is how much of a key is kept in clear to tell keys
+	// apart in listings
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+)
+
+// GenerateAPIKey returns a new random API key, the prefix it is listed by and
+// the hash it is stored as. The key itself is never stored.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, key[:apiKeyDisplayLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey hashes a key for lookup. Keys carry 256 random bits, so a plain
+// SHA-256 is enough where passwords need a slow hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// LooksLikeAPIKey reports whether key has the format of an API key
+func LooksLikeAPIKey(key string) bool {
+	return strings.HasPrefix(key, apiKeyPrefix) && len(key) > apiKeyDisplayLength
+}
@@ -0,0 +1,35 @@
+package auth
+
+import "context"
+
+// Identity is the authenticated caller of a request: an admin signed in with
+// a password, a player signed in to the mobile app through Firebase, or an
+// integration using an API key
+type Identity struct {
+	AdminID     string  `json:"admin_id,omitempty"`
+	APIKeyID    string  `json:"api_key_id,omitempty"`
+	Scopes      []Scope `json:"scopes,omitempty"`    // API keys only
+	PlayerID    string  `json:"player_id,omitempty"` // empty until the Firebase user is linked to a player
+	FirebaseUID string  `json:"firebase_uid,omitempty"`
+	Email       string  `json:"email"`
+	Role        string  `json:"role"`
+	// MustChangePassword holds an admin back until the temporary password
+	// they were invited or reset with is replaced
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+	// PasswordVersion ties admin tokens to the password they were issued
+	// under, so changing it signs out every session
+	PasswordVersion int64 `json:"-"`
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the caller identity
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns the caller identity stored by WithIdentity
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
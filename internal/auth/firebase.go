@@ -1 +1,326 @@
 package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FirebaseKeysURL serves the JWKS of the keys signing Firebase ID tokens
+const FirebaseKeysURL = "https://www.googleapis.com/service_accounts/v1/jwk/securetoken@system.gserviceaccount.com"
+
+const (
+	// defaultKeysMaxAge caches the keys when the response has no max-age
+	defaultKeysMaxAge = time.Hour
+	// keysRefetchInterval bounds how often an unknown key ID forces a refetch
+	keysRefetchInterval = time.Minute
+	// clockSkew is the leeway given to the iat and auth_time of a token
+	clockSkew = 5 * time.Minute
+)
+
+// ErrKeysUnavailable means the signing keys could not be fetched, so no
+// Firebase token can be verified for now
+var ErrKeysUnavailable = errors.New("firebase signing keys unavailable")
+
+// KeySource provides the public keys signing Firebase ID tokens by key ID,
+// along with how long they may be cached
+type KeySource interface {
+	FetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error)
+}
+
+// HTTPKeySource fetches the keys from a JWKS endpoint, honouring the
+// Cache-Control max-age Google sends with them
+type HTTPKeySource struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPKeySource creates a key source reading the JWKS at url
+func NewHTTPKeySource(url string) *HTTPKeySource {
+	return &HTTPKeySource{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+type jwk struct {
+	KeyID   string `json:"kid"`
+	KeyType string `json:"kty"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+func (s *HTTPKeySource) FetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("fetch %s: status %d", s.URL, resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, 0, fmt.Errorf("decode %s: %w", s.URL, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, key := range set.Keys {
+		if key.KeyType != "RSA" || key.KeyID == "" {
+			continue
+		}
+		publicKey, err := parseRSAKey(key.N, key.E)
+		if err != nil {
+			return nil, 0, fmt.Errorf("key %s: %w", key.KeyID, err)
+		}
+		keys[key.KeyID] = publicKey
+	}
+	if len(keys) == 0 {
+		return nil, 0, fmt.Errorf("fetch %s: no RSA keys", s.URL)
+	}
+
+	return keys, cacheMaxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+func parseRSAKey(n, e string) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}
+	if publicKey.N.Sign() == 0 || publicKey.E < 3 {
+		return nil, errors.New("invalid RSA key")
+	}
+	return publicKey, nil
+}
+
+// cacheMaxAge reads the max-age directive of a Cache-Control header
+func cacheMaxAge(header string) time.Duration {
+	for _, directive := range strings.Split(header, ",") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(directive), "max-age=")
+		if !ok {
+			continue
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultKeysMaxAge
+}
+
+// FirebaseToken is a verified Firebase ID token; UID is the Firebase user
+type FirebaseToken struct {
+	UID           string
+	Email         string
+	EmailVerified bool
+	PhoneNumber   string
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+}
+
+type firebaseHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type firebaseClaims struct {
+	Issuer        string `json:"iss"`
+	Audience      string `json:"aud"`
+	Subject       string `json:"sub"`
+	IssuedAt      int64  `json:"iat"`
+	ExpiresAt     int64  `json:"exp"`
+	AuthTime      int64  `json:"auth_time"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	PhoneNumber   string `json:"phone_number"`
+}
+
+// FirebaseVerifier checks Firebase ID tokens offline against the cached
+// signing keys of the project's securetoken issuer
+type FirebaseVerifier struct {
+	projectID string
+	source    KeySource
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	expiresAt time.Time
+	fetchedAt time.Time
+	fetchErr  error         // error of the last fetch, nil once one succeeds
+	fetching  chan struct{} // closed when the fetch in flight ends, nil when none is
+}
+
+// NewFirebaseVerifier creates a verifier accepting tokens of projectID signed
+// by the keys of source
+func NewFirebaseVerifier(projectID string, source KeySource) *FirebaseVerifier {
+	return &FirebaseVerifier{projectID: projectID, source: source}
+}
+
+// IsFirebaseToken reports whether token carries the RS256 header of a
+// Firebase ID token, telling it apart from the HS256 admin tokens. It does
+// not verify anything.
+func IsFirebaseToken(token string) bool {
+	header, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return false
+	}
+
+	var h firebaseHeader
+	return json.Unmarshal(decoded, &h) == nil && h.Algorithm == "RS256" && h.KeyID != ""
+}
+
+// Verify checks the signature, issuer, audience and lifetime of a Firebase ID
+// token. It returns ErrInvalidToken or ErrExpiredToken for a bad token and
+// ErrKeysUnavailable when the keys cannot be fetched.
+func (v *FirebaseVerifier) Verify(ctx context.Context, token string) (*FirebaseToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header firebaseHeader
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "RS256" || header.KeyID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	key, err := v.key(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims firebaseClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if claims.Issuer != "https://securetoken.google.com/"+v.projectID || claims.Audience != v.projectID {
+		return nil, ErrInvalidToken
+	}
+	if claims.Subject == "" || len(claims.Subject) > 128 {
+		return nil, ErrInvalidToken
+	}
+	if time.Unix(claims.IssuedAt, 0).After(now.Add(clockSkew)) || time.Unix(claims.AuthTime, 0).After(now.Add(clockSkew)) {
+		return nil, ErrInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &FirebaseToken{
+		UID:           claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		PhoneNumber:   claims.PhoneNumber,
+		IssuedAt:      time.Unix(claims.IssuedAt, 0),
+		ExpiresAt:     time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+// key returns the signing key keyID, refreshing the cache once it expires or
+// when the key is unknown, as Google rotates them. One request fetches at a
+// time without holding the lock, the others wait for its result. A failed
+// refresh falls back to the cached keys while they still hold keyID.
+func (v *FirebaseVerifier) key(ctx context.Context, keyID string) (*rsa.PublicKey, error) {
+	v.mu.Lock()
+	now := time.Now()
+	key, known := v.keys[keyID]
+	if known && now.Before(v.expiresAt) {
+		v.mu.Unlock()
+		return key, nil
+	}
+	if !known && v.keys != nil && now.Before(v.expiresAt) && now.Sub(v.fetchedAt) < keysRefetchInterval {
+		v.mu.Unlock()
+		return nil, ErrInvalidToken
+	}
+
+	done := v.fetching
+	if done == nil {
+		done = make(chan struct{})
+		v.fetching = done
+		v.mu.Unlock()
+		v.refresh(ctx, done)
+	} else {
+		v.mu.Unlock()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", ErrKeysUnavailable, ctx.Err())
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if key, ok := v.keys[keyID]; ok {
+		return key, nil
+	}
+	if v.fetchErr != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeysUnavailable, v.fetchErr)
+	}
+	return nil, ErrInvalidToken
+}
+
+// refresh fetches the keys and stores them, or the error, for every request
+// waiting on done. The fetch is not cancelled with the request that started
+// it, as the others depend on it; the key source bounds how long it takes.
+func (v *FirebaseVerifier) refresh(ctx context.Context, done chan struct{}) {
+	keys, maxAge, err := v.source.FetchKeys(context.WithoutCancel(ctx))
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.fetchErr = err
+	if err == nil {
+		now := time.Now()
+		v.keys = keys
+		v.fetchedAt = now
+		v.expiresAt = now.Add(maxAge)
+	}
+	v.fetching = nil
+	close(done)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	decoded, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decoded, v)
+}
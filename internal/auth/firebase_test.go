@@ -0,0 +1,298 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testProjectID = "ping-pong-test"
+
+// memoryKeySource serves fixed keys and counts the fetches
+type memoryKeySource struct {
+	keys   map[string]*rsa.PublicKey
+	maxAge time.Duration
+	err    error
+	calls  int
+}
+
+func (s *memoryKeySource) FetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	keys := make(map[string]*rsa.PublicKey, len(s.keys))
+	for kid, key := range s.keys {
+		keys[kid] = key
+	}
+	return keys, s.maxAge, nil
+}
+
+// blockingKeySource holds every fetch until release is closed
+type blockingKeySource struct {
+	keys    map[string]*rsa.PublicKey
+	started chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *blockingKeySource) FetchKeys(ctx context.Context) (map[string]*rsa.PublicKey, time.Duration, error) {
+	if s.calls.Add(1) == 1 {
+		close(s.started)
+	}
+	<-s.release
+	return s.keys, time.Hour, nil
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	t.Helper()
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signed := encode(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"}) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]interface{} {
+	now := time.Now()
+	return map[string]interface{}{
+		"iss":          "https://securetoken.google.com/" + testProjectID,
+		"aud":          testProjectID,
+		"sub":          "firebase-uid",
+		"iat":          now.Add(-time.Minute).Unix(),
+		"exp":          now.Add(time.Hour).Unix(),
+		"auth_time":    now.Add(-time.Minute).Unix(),
+		"phone_number": "+84901234567",
+	}
+}
+
+func TestFirebaseVerify(t *testing.T) {
+	key := generateKey(t)
+	otherKey := generateKey(t)
+
+	tests := []struct {
+		name    string
+		key     *rsa.PrivateKey
+		claims  func(map[string]interface{})
+		wantErr error
+	}{
+		{name: "valid token", key: key},
+		{
+			name:    "wrong audience",
+			key:     key,
+			claims:  func(c map[string]interface{}) { c["aud"] = "other-project" },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong issuer",
+			key:     key,
+			claims:  func(c map[string]interface{}) { c["iss"] = "https://securetoken.google.com/other-project" },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "expired",
+			key:     key,
+			claims:  func(c map[string]interface{}) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:    "issued in the future",
+			key:     key,
+			claims:  func(c map[string]interface{}) { c["iat"] = time.Now().Add(time.Hour).Unix() },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "missing subject",
+			key:     key,
+			claims:  func(c map[string]interface{}) { delete(c, "sub") },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "signed by another key",
+			key:     otherKey,
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := &memoryKeySource{keys: map[string]*rsa.PublicKey{"kid-1": &key.PublicKey}, maxAge: time.Hour}
+			verifier := NewFirebaseVerifier(testProjectID, source)
+
+			claims := validClaims()
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+			token := signToken(t, tt.key, "kid-1", claims)
+			if !IsFirebaseToken(token) {
+				t.Fatalf("IsFirebaseToken = false, want true")
+			}
+
+			got, err := verifier.Verify(context.Background(), token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.UID != "firebase-uid" || got.PhoneNumber != "+84901234567") {
+				t.Errorf("Verify = %+v, want uid firebase-uid and phone +84901234567", got)
+			}
+		})
+	}
+}
+
+func TestFirebaseUnknownKeyRefetch(t *testing.T) {
+	oldKey := generateKey(t)
+	newKey := generateKey(t)
+	source := &memoryKeySource{keys: map[string]*rsa.PublicKey{"kid-old": &oldKey.PublicKey}, maxAge: time.Hour}
+	verifier := NewFirebaseVerifier(testProjectID, source)
+	ctx := context.Background()
+
+	if _, err := verifier.Verify(ctx, signToken(t, oldKey, "kid-old", validClaims())); err != nil {
+		t.Fatalf("Verify old key: %v", err)
+	}
+	if source.calls != 1 {
+		t.Fatalf("fetches = %d, want 1", source.calls)
+	}
+
+	// Google rotates in a new key
+	source.keys = map[string]*rsa.PublicKey{"kid-old": &oldKey.PublicKey, "kid-new": &newKey.PublicKey}
+	newToken := signToken(t, newKey, "kid-new", validClaims())
+
+	// Right after a fetch, an unknown key ID does not hit the source again
+	if _, err := verifier.Verify(ctx, newToken); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Verify within refetch interval error = %v, want %v", err, ErrInvalidToken)
+	}
+	if source.calls != 1 {
+		t.Fatalf("fetches within refetch interval = %d, want 1", source.calls)
+	}
+
+	verifier.fetchedAt = time.Now().Add(-2 * keysRefetchInterval)
+	if _, err := verifier.Verify(ctx, newToken); err != nil {
+		t.Fatalf("Verify after refetch interval: %v", err)
+	}
+	if source.calls != 2 {
+		t.Fatalf("fetches after refetch interval = %d, want 2", source.calls)
+	}
+
+	// A key ID the source does not know either is rejected after the refetch
+	verifier.fetchedAt = time.Now().Add(-2 * keysRefetchInterval)
+	if _, err := verifier.Verify(ctx, signToken(t, newKey, "kid-unknown", validClaims())); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Verify unknown key error = %v, want %v", err, ErrInvalidToken)
+	}
+	if source.calls != 3 {
+		t.Fatalf("fetches for unknown key = %d, want 3", source.calls)
+	}
+}
+
+func TestFirebaseKeysUnavailable(t *testing.T) {
+	key := generateKey(t)
+	source := &memoryKeySource{err: errors.New("connection refused")}
+	verifier := NewFirebaseVerifier(testProjectID, source)
+	token := signToken(t, key, "kid-1", validClaims())
+	ctx := context.Background()
+
+	if _, err := verifier.Verify(ctx, token); !errors.Is(err, ErrKeysUnavailable) {
+		t.Fatalf("Verify error = %v, want %v", err, ErrKeysUnavailable)
+	}
+
+	// Once fetched, expired keys are still used while the source is down
+	source.err = nil
+	source.keys = map[string]*rsa.PublicKey{"kid-1": &key.PublicKey}
+	source.maxAge = time.Hour
+	if _, err := verifier.Verify(ctx, token); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	source.err = errors.New("connection refused")
+	verifier.expiresAt = time.Now().Add(-time.Minute)
+	if _, err := verifier.Verify(ctx, token); err != nil {
+		t.Fatalf("Verify with stale keys: %v", err)
+	}
+	if _, err := verifier.Verify(ctx, signToken(t, key, "kid-2", validClaims())); !errors.Is(err, ErrKeysUnavailable) {
+		t.Fatalf("Verify unknown key while unavailable error = %v, want %v", err, ErrKeysUnavailable)
+	}
+}
+
+func TestFirebaseRefreshDoesNotBlockCachedKeys(t *testing.T) {
+	oldKey := generateKey(t)
+	newKey := generateKey(t)
+	source := &blockingKeySource{
+		keys:    map[string]*rsa.PublicKey{"kid-old": &oldKey.PublicKey, "kid-new": &newKey.PublicKey},
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	verifier := NewFirebaseVerifier(testProjectID, source)
+	verifier.keys = map[string]*rsa.PublicKey{"kid-old": &oldKey.PublicKey}
+	verifier.expiresAt = time.Now().Add(time.Hour)
+	verifier.fetchedAt = time.Now().Add(-2 * keysRefetchInterval)
+	ctx := context.Background()
+
+	// Concurrent requests signed by the new key share a single fetch
+	newToken := signToken(t, newKey, "kid-new", validClaims())
+	errs := make(chan error, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.Verify(ctx, newToken)
+			errs <- err
+		}()
+	}
+	<-source.started
+
+	// While the fetch is in flight, tokens of cached keys still verify
+	oldToken := signToken(t, oldKey, "kid-old", validClaims())
+	verified := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(ctx, oldToken)
+		verified <- err
+	}()
+	select {
+	case err := <-verified:
+		if err != nil {
+			t.Fatalf("Verify cached key during refresh: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Verify cached key blocked behind the key fetch")
+	}
+
+	close(source.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Verify new key: %v", err)
+		}
+	}
+	if calls := source.calls.Load(); calls != 1 {
+		t.Errorf("fetches = %d, want 1", calls)
+	}
+}
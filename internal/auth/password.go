@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// argon2idPrefix starts a hash in the PHC string format,
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+const argon2idPrefix = "$argon2id$"
+
+// Password policy
+const (
+	MinPasswordLength = 10
+	// maxPasswordBytes is where bcrypt stops reading a password
+	maxPasswordBytes = 72
+)
+
+var (
+	ErrPasswordTooShort    = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+	ErrPasswordTooLong     = fmt.Errorf("password must be at most %d bytes", maxPasswordBytes)
+	ErrPasswordComposition = errors.New("password must contain letters and digits")
+	ErrPasswordHasEmail    = errors.New("password must not contain the email name")
+)
+
+// ValidatePassword checks password against the policy for the account email
+func ValidatePassword(password, email string) error {
+	if len([]rune(password)) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > maxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+
+	var letter, digit bool
+	for _, r := range password {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	if !letter || !digit {
+		return ErrPasswordComposition
+	}
+
+	name, _, _ := strings.Cut(strings.ToLower(email), "@")
+	if len(name) >= 3 && strings.Contains(strings.ToLower(password), name) {
+		return ErrPasswordHasEmail
+	}
+	return nil
+}
+
+// temporaryPasswordAlphabet leaves out characters that are easily misread
+const temporaryPasswordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateTemporaryPassword returns a random password meeting the policy,
+// handed out to invited and reset accounts
+func GenerateTemporaryPassword() (string, error) {
+	size := big.NewInt(int64(len(temporaryPasswordAlphabet)))
+	for {
+		password := make([]byte, 16)
+		for i := range password {
+			n, err := rand.Int(rand.Reader, size)
+			if err != nil {
+				return "", err
+			}
+			password[i] = temporaryPasswordAlphabet[n.Int64()]
+		}
+
+		if ValidatePassword(string(password), "") == nil {
+			return string(password), nil
+		}
+	}
+}
+
+// HashPassword hashes a password with bcrypt
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// VerifyPassword reports whether password matches hash. Argon2id hashes in
+// the PHC string format are supported next to bcrypt, so accounts created
+// by other tools can sign in.
+func VerifyPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return verifyArgon2id(hash, password)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func verifyArgon2id(hash, password string) bool {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false
+	}
+
+	derived := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(derived, key) == 1
+}
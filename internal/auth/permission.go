@@ -0,0 +1,96 @@
+package auth
+
+import "backend-ping-pong-app/internal/models"
+
+// Permission is what a route requires from its caller
+type Permission string
+
+const (
+	// PermissionPublic routes need no caller at all
+	PermissionPublic Permission = "public"
+	// PermissionRead covers the back office reads (audit trails, job status)
+	PermissionRead Permission = "read"
+	// PermissionRecordResults covers recording rubber and tournament results
+	PermissionRecordResults Permission = "results:record"
+	// PermissionSubmitLineup covers submitting a team lineup; captains only
+	// for their own team
+	PermissionSubmitLineup Permission = "lineups:submit"
+	// PermissionManageLineups covers every team's lineups and locking them
+	PermissionManageLineups Permission = "lineups:manage"
+	// PermissionManageLeague covers seasons, teams, players, fixtures, rounds,
+	// ranks, brackets and tournaments
+	PermissionManageLeague Permission = "league:manage"
+	// PermissionManagePoints covers point adjustments and result amendments
+	PermissionManagePoints Permission = "points:manage"
+	// PermissionManageUsers covers admin accounts, team captains and API keys
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionViewProfile covers a player's full profile; players only
+	// their own
+	PermissionViewProfile Permission = "profiles:view"
+	// PermissionEditProfile covers editing a player's profile; players only
+	// their own
+	PermissionEditProfile Permission = "profiles:edit"
+)
+
+// RolePlayer is the role of players signed in to the mobile app
+const RolePlayer = "PLAYER"
+
+// rolePermissions lists what every role is granted. Each admin role includes
+// the permissions of the roles below it.
+var rolePermissions = map[string][]Permission{
+	RolePlayer: {
+		PermissionViewProfile,
+		PermissionEditProfile,
+	},
+	models.AdminRoleViewer: {
+		PermissionRead,
+		PermissionViewProfile,
+	},
+	models.AdminRoleCaptain: {
+		PermissionRead,
+		PermissionViewProfile,
+		PermissionSubmitLineup,
+	},
+	models.AdminRoleModerator: {
+		PermissionRead,
+		PermissionViewProfile,
+		PermissionSubmitLineup,
+		PermissionManageLineups,
+		PermissionRecordResults,
+	},
+	models.AdminRoleAdmin: {
+		PermissionRead,
+		PermissionViewProfile,
+		PermissionEditProfile,
+		PermissionSubmitLineup,
+		PermissionManageLineups,
+		PermissionRecordResults,
+		PermissionManageLeague,
+		PermissionManagePoints,
+		PermissionManageUsers,
+	},
+}
+
+// HasScope reports whether the caller is an API key granted scope
+func (i *Identity) HasScope(scope Scope) bool {
+	for _, granted := range i.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Can reports whether the caller's role grants permission
+func (i *Identity) Can(permission Permission) bool {
+	if permission == PermissionPublic {
+		return true
+	}
+
+	for _, granted := range rolePermissions[i.Role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
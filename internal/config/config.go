@@ -2,20 +2,87 @@ package config
 
 import (
 	"os"
+	"time"
 
+	log "github.com/jeanphorn/log4go"
 	"github.com/joho/godotenv"
+
+	"backend-ping-pong-app/internal/auth"
 )
 
 // Config holds all application configuration
 type Config struct {
 	App      AppConfig
 	Database DatabaseConfig
+	League   LeagueConfig
+	Jobs     JobsConfig
+	Auth     AuthConfig
 }
 
 type AppConfig struct {
 	Port string
 }
 
+// LeagueConfig holds the match format rules of the league
+type LeagueConfig struct {
+	BestOf            int   // number of sets in a rubber (3, 5 or 7)
+	RubbersPerFixture int   // number of rubbers played in a fixture
+	RubbersToWin      int   // rubbers needed to win a fixture, e.g. first to 5 of 9
+	DoublesRubbers    []int // match orders played as doubles, the others are singles
+}
+
+// IsDoublesRubber reports whether the rubber at matchOrder is played as doubles
+func (l LeagueConfig) IsDoublesRubber(matchOrder int) bool {
+	for _, order := range l.DoublesRubbers {
+		if order == matchOrder {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaults replaces the match format values no fixture can be played
+// with by the defaults: an odd best-of, at least one rubber per fixture and a
+// winning margin that can be reached. An unset RubbersToWin is a majority of
+// the rubbers.
+func (l LeagueConfig) withDefaults() LeagueConfig {
+	if l.BestOf <= 0 || l.BestOf%2 == 0 {
+		log.Warn("LEAGUE_BEST_OF=%d must be odd and positive, using 5", l.BestOf)
+		l.BestOf = 5
+	}
+	if l.RubbersPerFixture <= 0 {
+		log.Warn("LEAGUE_RUBBERS_PER_FIXTURE=%d must be positive, using 9", l.RubbersPerFixture)
+		l.RubbersPerFixture = 9
+	}
+	if l.RubbersToWin == 0 {
+		l.RubbersToWin = l.RubbersPerFixture/2 + 1
+	}
+	if l.RubbersToWin < 0 || l.RubbersToWin > l.RubbersPerFixture {
+		log.Warn("LEAGUE_RUBBERS_TO_WIN=%d must be between 1 and %d, using %d", l.RubbersToWin, l.RubbersPerFixture, l.RubbersPerFixture/2+1)
+		l.RubbersToWin = l.RubbersPerFixture/2 + 1
+	}
+	return l
+}
+
+// JobsConfig holds the background job schedule
+type JobsConfig struct {
+	Enabled                    bool
+	LeaderboardRefreshInterval time.Duration
+	RankRecalcInterval         time.Duration
+	LineupLockInterval         time.Duration
+}
+
+// AuthConfig holds the signing settings of admin tokens. Without a secret a
+// random one is generated, so tokens do not survive a restart. Firebase
+// sign-in for players is off until a project ID is set.
+type AuthConfig struct {
+	JWTSecret         string
+	AccessTokenTTL    time.Duration
+	RefreshTokenTTL   time.Duration
+	FirebaseProjectID string
+	FirebaseKeysURL   string
+}
+
 type DatabaseConfig struct {
 	Host     string
 	Port     string
@@ -27,6 +94,13 @@ type DatabaseConfig struct {
 func Load() *Config {
 	_ = godotenv.Load() // load .env, ignore error nếu chạy production
 
+	league := LeagueConfig{
+		BestOf:            getEnvInt("LEAGUE_BEST_OF", 5),
+		RubbersPerFixture: getEnvInt("LEAGUE_RUBBERS_PER_FIXTURE", 9),
+		RubbersToWin:      getEnvInt("LEAGUE_RUBBERS_TO_WIN", 0),
+		DoublesRubbers:    getEnvInts("LEAGUE_DOUBLES_RUBBERS", nil),
+	}
+
 	return &Config{
 		App: AppConfig{
 			Port: getEnv("PORT", "8080"),
@@ -38,5 +112,19 @@ func Load() *Config {
 			Password: os.Getenv("DB_PASSWORD"),
 			Name:     getEnv("DB_NAME", "pingpong"),
 		},
+		League: league.withDefaults(),
+		Jobs: JobsConfig{
+			Enabled:                    getEnvBool("JOBS_ENABLED", true),
+			LeaderboardRefreshInterval: getEnvDuration("JOB_LEADERBOARD_REFRESH_INTERVAL", 5*time.Minute),
+			RankRecalcInterval:         getEnvDuration("JOB_RANK_RECALC_INTERVAL", time.Hour),
+			LineupLockInterval:         getEnvDuration("JOB_LINEUP_LOCK_INTERVAL", time.Minute),
+		},
+		Auth: AuthConfig{
+			JWTSecret:         os.Getenv("AUTH_JWT_SECRET"),
+			AccessTokenTTL:    getEnvDuration("AUTH_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:   getEnvDuration("AUTH_REFRESH_TOKEN_TTL", 7*24*time.Hour),
+			FirebaseProjectID: os.Getenv("FIREBASE_PROJECT_ID"),
+			FirebaseKeysURL:   getEnv("FIREBASE_KEYS_URL", auth.FirebaseKeysURL),
+		},
 	}
 }
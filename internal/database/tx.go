@@ -0,0 +1,35 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	log "github.com/jeanphorn/log4go"
+)
+
+// WithTx runs fn inside a transaction. The transaction is committed when fn
+// returns nil and rolled back when fn returns an error or panics.
+func WithTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				log.Error("Failed to rollback transaction: %v", rbErr)
+			}
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -22,6 +22,10 @@ const (
 	ErrorTeamNotFound    = "TEAM_NOT_FOUND"
 	ErrorTeamInvalidData = "TEAM_INVALID_DATA"
 
+	// Transfer errors
+	ErrorTransferWindowClosed = "TRANSFER_WINDOW_CLOSED"
+	ErrorTransferLimitReached = "TRANSFER_LIMIT_REACHED"
+
 	// Rank errors
 	ErrorRankNotFound = "RANK_NOT_FOUND"
 
@@ -30,16 +34,38 @@ const (
 	ErrorPlayerSeasonNotFound  = "PLAYER_SEASON_NOT_FOUND"
 
 	// Fixture errors
-	ErrorFixtureNotFound  = "FIXTURE_NOT_FOUND"
-	ErrorFixtureNotActive = "FIXTURE_NOT_ACTIVE"
-	ErrorInvalidTeamMatch = "INVALID_TEAM_MATCH"
-	ErrorSameTeamMatch    = "SAME_TEAM_MATCH"
+	ErrorFixtureNotFound    = "FIXTURE_NOT_FOUND"
+	ErrorFixtureNotActive   = "FIXTURE_NOT_ACTIVE"
+	ErrorInvalidTeamMatch   = "INVALID_TEAM_MATCH"
+	ErrorSameTeamMatch      = "SAME_TEAM_MATCH"
+	ErrorScheduleLocked     = "SCHEDULE_LOCKED"
+	ErrorScheduleHasBracket = "SCHEDULE_HAS_BRACKET"
+
+	// Lineup errors
+	ErrorLineupNotFound = "LINEUP_NOT_FOUND"
+	ErrorLineupLocked   = "LINEUP_LOCKED"
+	ErrorLineupInvalid  = "LINEUP_INVALID"
+
+	// Bracket errors
+	ErrorBracketNotFound = "BRACKET_NOT_FOUND"
+	ErrorKnockoutDraw    = "KNOCKOUT_DRAW"
+
+	// Tournament errors
+	ErrorTournamentNotFound     = "TOURNAMENT_NOT_FOUND"
+	ErrorTournamentInvalidState = "TOURNAMENT_INVALID_STATE"
+
+	// Round errors
+	ErrorRoundAlreadyFinalized = "ROUND_ALREADY_FINALIZED"
+	ErrorRoundNotReady         = "ROUND_NOT_READY"
+	ErrorRoundNotFinalized     = "ROUND_NOT_FINALIZED"
+	ErrorPreviousRoundOpen     = "PREVIOUS_ROUND_NOT_FINALIZED"
 
 	// Match errors
 	ErrorMatchNotFound        = "MATCH_NOT_FOUND"
 	ErrorMatchInvalidSets     = "MATCH_INVALID_SETS"
 	ErrorInvalidPlayers       = "INVALID_PLAYERS"
 	ErrorMatchAlreadyRecorded = "MATCH_ALREADY_RECORDED"
+	ErrorMatchNotRecorded     = "MATCH_NOT_RECORDED"
 
 	// Point errors
 	ErrorInvalidPointAdjustment = "INVALID_POINT_ADJUSTMENT"
@@ -53,9 +79,17 @@ const (
 	ErrorInvalidInput    = "INVALID_INPUT"
 	ErrorMissingRequired = "MISSING_REQUIRED_FIELD"
 
-	// Authorization errors (future)
-	ErrorUnauthorized = "UNAUTHORIZED"
-	ErrorForbidden    = "FORBIDDEN"
+	// Authorization errors
+	ErrorUnauthorized           = "UNAUTHORIZED"
+	ErrorForbidden              = "FORBIDDEN"
+	ErrorAdminNotFound          = "ADMIN_NOT_FOUND"
+	ErrorAuthUnavailable        = "AUTH_UNAVAILABLE"
+	ErrorPlayerAccountLinked    = "PLAYER_ACCOUNT_LINKED"
+	ErrorAdminAlreadyExists     = "ADMIN_ALREADY_EXISTS"
+	ErrorWeakPassword           = "WEAK_PASSWORD"
+	ErrorPasswordChangeRequired = "PASSWORD_CHANGE_REQUIRED"
+	ErrorLastAdmin              = "LAST_ADMIN"
+	ErrorAPIKeyNotFound         = "API_KEY_NOT_FOUND"
 )
 
 // ==================== Custom Error Type ====================
@@ -106,6 +140,10 @@ func PlayerAlreadyExists() *AppError {
 	return NewAppError(ErrorPlayerAlreadyExists, "VĐV đã tồn tại", 409)
 }
 
+func PlayerInactive() *AppError {
+	return NewAppError(ErrorPlayerInactive, "VĐV không ở trạng thái thi đấu", 400)
+}
+
 func SeasonNotFound() *AppError {
 	return NewAppError(ErrorSeasonNotFound, "Mùa giải không tồn tại", 404)
 }
@@ -114,6 +152,14 @@ func TeamNotFound() *AppError {
 	return NewAppError(ErrorTeamNotFound, "Đội bóng không tồn tại", 404)
 }
 
+func TransferWindowClosed() *AppError {
+	return NewAppError(ErrorTransferWindowClosed, "Ngoài thời gian chuyển nhượng", 409)
+}
+
+func TransferLimitReached() *AppError {
+	return NewAppError(ErrorTransferLimitReached, "VĐV đã hết số lần chuyển nhượng trong mùa giải", 409)
+}
+
 func RankNotFound() *AppError {
 	return NewAppError(ErrorRankNotFound, "Hạng trình độ không tồn tại", 404)
 }
@@ -130,10 +176,74 @@ func FixtureNotFound() *AppError {
 	return NewAppError(ErrorFixtureNotFound, "Trận đấu CLB không tồn tại", 404)
 }
 
+func FixtureNotActive() *AppError {
+	return NewAppError(ErrorFixtureNotActive, "Trận đấu CLB không còn ở trạng thái có thể thay đổi", 409)
+}
+
+func InvalidTeamMatch() *AppError {
+	return NewAppError(ErrorInvalidTeamMatch, "Đội bóng không tham gia trận đấu CLB này", 400)
+}
+
+func ScheduleLocked() *AppError {
+	return NewAppError(ErrorScheduleLocked, "Lịch thi đấu đã có trận đang diễn ra hoặc đã kết thúc", 409)
+}
+
+func ScheduleHasBracket() *AppError {
+	return NewAppError(ErrorScheduleHasBracket, "Mùa giải đã có nhánh đấu loại trực tiếp, không thể xếp lại lịch", 409)
+}
+
+func LineupNotFound() *AppError {
+	return NewAppError(ErrorLineupNotFound, "Chưa có đội hình được đăng ký cho trận đấu CLB", 404)
+}
+
+func LineupLocked() *AppError {
+	return NewAppError(ErrorLineupLocked, "Đội hình đã bị khóa", 409)
+}
+
+func LineupInvalid(message string) *AppError {
+	return NewAppError(ErrorLineupInvalid, message, 400)
+}
+
+func BracketNotFound() *AppError {
+	return NewAppError(ErrorBracketNotFound, "Nhánh đấu loại trực tiếp không tồn tại", 404)
+}
+
+func KnockoutDraw() *AppError {
+	return NewAppError(ErrorKnockoutDraw, "Trận đấu loại trực tiếp phải có đội thắng", 409)
+}
+
+func TournamentNotFound() *AppError {
+	return NewAppError(ErrorTournamentNotFound, "Giải đấu cá nhân không tồn tại", 404)
+}
+
+func TournamentInvalidState() *AppError {
+	return NewAppError(ErrorTournamentInvalidState, "Giải đấu không ở trạng thái cho phép thao tác này", 409)
+}
+
+func RoundAlreadyFinalized() *AppError {
+	return NewAppError(ErrorRoundAlreadyFinalized, "Vòng đấu đã được chốt kết quả", 409)
+}
+
+func RoundNotReady() *AppError {
+	return NewAppError(ErrorRoundNotReady, "Vòng đấu còn trận chưa kết thúc", 409)
+}
+
+func RoundNotFinalized() *AppError {
+	return NewAppError(ErrorRoundNotFinalized, "Vòng đấu chưa được chốt kết quả", 404)
+}
+
+func PreviousRoundOpen() *AppError {
+	return NewAppError(ErrorPreviousRoundOpen, "Các vòng trước chưa được chốt kết quả", 409)
+}
+
 func MatchNotFound() *AppError {
 	return NewAppError(ErrorMatchNotFound, "Trận đấu con không tồn tại", 404)
 }
 
+func MatchInvalidSets(message string) *AppError {
+	return NewAppError(ErrorMatchInvalidSets, message, 400)
+}
+
 func InvalidPointAdjustment() *AppError {
 	return NewAppError(ErrorInvalidPointAdjustment, "Điểm điều chỉnh không hợp lệ", 400)
 }
@@ -158,6 +268,62 @@ func MatchAlreadyRecorded() *AppError {
 	return NewAppError(ErrorMatchAlreadyRecorded, "Trận đấu đã được ghi lại kết quả", 409)
 }
 
+func MatchNotRecorded() *AppError {
+	return NewAppError(ErrorMatchNotRecorded, "Trận đấu chưa được ghi nhận kết quả", 409)
+}
+
 func NegativePointsResult() *AppError {
 	return NewAppError(ErrorNegativePointsResult, "Điểm không thể là số âm", 400)
 }
+
+func Unauthorized() *AppError {
+	return NewAppError(ErrorUnauthorized, "Bạn cần đăng nhập để thực hiện thao tác này", 401)
+}
+
+func InvalidCredentials() *AppError {
+	return NewAppError(ErrorUnauthorized, "Email hoặc mật khẩu không đúng", 401)
+}
+
+func TokenExpired() *AppError {
+	return NewAppError(ErrorUnauthorized, "Phiên đăng nhập đã hết hạn", 401)
+}
+
+func Forbidden() *AppError {
+	return NewAppError(ErrorForbidden, "Bạn không có quyền thực hiện thao tác này", 403)
+}
+
+func AdminNotFound() *AppError {
+	return NewAppError(ErrorAdminNotFound, "Tài khoản quản trị không tồn tại", 404)
+}
+
+func AuthUnavailable() *AppError {
+	return NewAppError(ErrorAuthUnavailable, "Không thể xác thực lúc này, vui lòng thử lại sau", 503)
+}
+
+func PlayerAccountLinked() *AppError {
+	return NewAppError(ErrorPlayerAccountLinked, "Tài khoản ứng dụng đã được liên kết với VĐV khác", 409)
+}
+
+func AdminAlreadyExists() *AppError {
+	return NewAppError(ErrorAdminAlreadyExists, "Email đã được sử dụng cho tài khoản quản trị khác", 409)
+}
+
+func WeakPassword() *AppError {
+	return NewAppError(ErrorWeakPassword, "Mật khẩu phải có ít nhất 10 ký tự, gồm cả chữ và số, và không chứa tên email", 400)
+}
+
+func PasswordChangeRequired() *AppError {
+	return NewAppError(ErrorPasswordChangeRequired, "Bạn cần đổi mật khẩu trước khi tiếp tục", 403)
+}
+
+func LastAdmin() *AppError {
+	return NewAppError(ErrorLastAdmin, "Không thể hạ quyền hoặc vô hiệu hóa quản trị viên cuối cùng", 409)
+}
+
+func APIKeyNotFound() *AppError {
+	return NewAppError(ErrorAPIKeyNotFound, "API key không tồn tại", 404)
+}
+
+func InvalidAPIKey() *AppError {
+	return NewAppError(ErrorUnauthorized, "API key không hợp lệ hoặc đã bị thu hồi", 401)
+}
@@ -0,0 +1,88 @@
+package handicap
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// DefaultStep is the standard score difference worth one starting point;
+	// adjacent ranks are 10 apart so each rank of difference is one point
+	DefaultStep = 10
+
+	// DefaultMaxPoints caps the starting advantage in an 11 point set
+	DefaultMaxPoints = 7
+)
+
+// ErrInvalidSide means a side has no player, or a player without a standard
+// score, so there is no handicap to give
+var ErrInvalidSide = errors.New("handicap side without a standard score")
+
+// Formula turns the standard score gap between two sides into starting
+// points for the weaker side
+type Formula struct {
+	Step      int `json:"step"`
+	MaxPoints int `json:"max_points"`
+}
+
+// Result is the handicap of one rubber. Doubles pairs play with the average
+// standard score of both partners. Only the weaker side gets a head start.
+type Result struct {
+	HomeStandardScore  float64 `json:"home_standard_score"`
+	GuestStandardScore float64 `json:"guest_standard_score"`
+	Difference         float64 `json:"difference"`
+	HomeStartPoints    int     `json:"home_start_points"`
+	GuestStartPoints   int     `json:"guest_start_points"`
+	Formula            Formula `json:"formula"`
+}
+
+// Calculate returns the starting points of each side per set from the
+// standard scores of their players' ranks. It returns ErrInvalidSide when a
+// side is empty or holds a score that is not positive, as an unknown rank has.
+func (f Formula) Calculate(home, guest []int) (Result, error) {
+	if !validSide(home) || !validSide(guest) {
+		return Result{}, ErrInvalidSide
+	}
+
+	result := Result{
+		HomeStandardScore:  average(home),
+		GuestStandardScore: average(guest),
+		Formula:            f,
+	}
+	result.Difference = math.Abs(result.HomeStandardScore - result.GuestStandardScore)
+
+	points := 0
+	if f.Step > 0 {
+		points = int(result.Difference) / f.Step
+	}
+	if points > f.MaxPoints {
+		points = f.MaxPoints
+	}
+
+	if result.HomeStandardScore < result.GuestStandardScore {
+		result.HomeStartPoints = points
+	} else {
+		result.GuestStartPoints = points
+	}
+	return result, nil
+}
+
+func validSide(scores []int) bool {
+	if len(scores) == 0 {
+		return false
+	}
+	for _, score := range scores {
+		if score <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func average(scores []int) float64 {
+	sum := 0
+	for _, score := range scores {
+		sum += score
+	}
+	return float64(sum) / float64(len(scores))
+}
@@ -0,0 +1,134 @@
+package handicap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFormulaCalculate(t *testing.T) {
+	standard := Formula{Step: DefaultStep, MaxPoints: DefaultMaxPoints}
+
+	tests := []struct {
+		name       string
+		formula    Formula
+		home       []int
+		guest      []int
+		difference float64
+		homeStart  int
+		guestStart int
+	}{
+		{
+			name:    "equal ranks start level",
+			formula: standard,
+			home:    []int{100},
+			guest:   []int{100},
+		},
+		{
+			name:       "weaker home player gets a head start",
+			formula:    standard,
+			home:       []int{80},
+			guest:      []int{100},
+			difference: 20,
+			homeStart:  2,
+		},
+		{
+			name:       "weaker guest player gets a head start",
+			formula:    standard,
+			home:       []int{110},
+			guest:      []int{70},
+			difference: 40,
+			guestStart: 4,
+		},
+		{
+			name:       "partial steps are dropped",
+			formula:    standard,
+			home:       []int{100},
+			guest:      []int{115},
+			difference: 15,
+			homeStart:  1,
+		},
+		{
+			name:       "less than a step starts level",
+			formula:    standard,
+			home:       []int{95},
+			guest:      []int{100},
+			difference: 5,
+		},
+		{
+			name:       "capped at max points",
+			formula:    standard,
+			home:       []int{50},
+			guest:      []int{130},
+			difference: 80,
+			homeStart:  7,
+		},
+		{
+			name:       "doubles pairs play at their average",
+			formula:    standard,
+			home:       []int{80, 100},
+			guest:      []int{110, 120},
+			difference: 25,
+			homeStart:  2,
+		},
+		{
+			name:       "custom step and cap",
+			formula:    Formula{Step: 5, MaxPoints: 3},
+			home:       []int{100},
+			guest:      []int{120},
+			difference: 20,
+			homeStart:  3,
+		},
+		{
+			name:       "zero step gives no points",
+			formula:    Formula{Step: 0, MaxPoints: 7},
+			home:       []int{60},
+			guest:      []int{120},
+			difference: 60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.formula.Calculate(tt.home, tt.guest)
+			if err != nil {
+				t.Fatalf("Calculate: %v", err)
+			}
+			if got.Difference != tt.difference {
+				t.Errorf("Difference = %v, want %v", got.Difference, tt.difference)
+			}
+			if got.HomeStartPoints != tt.homeStart || got.GuestStartPoints != tt.guestStart {
+				t.Errorf("start points = %d-%d, want %d-%d", got.HomeStartPoints, got.GuestStartPoints, tt.homeStart, tt.guestStart)
+			}
+			if got.Formula != tt.formula {
+				t.Errorf("Formula = %+v, want %+v", got.Formula, tt.formula)
+			}
+		})
+	}
+}
+
+func TestFormulaCalculateInvalidSide(t *testing.T) {
+	formula := Formula{Step: DefaultStep, MaxPoints: DefaultMaxPoints}
+
+	tests := []struct {
+		name  string
+		home  []int
+		guest []int
+	}{
+		{name: "no home player", home: nil, guest: []int{100}},
+		{name: "no guest player", home: []int{100}, guest: []int{}},
+		{name: "unknown rank", home: []int{0}, guest: []int{100}},
+		{name: "doubles partner with unknown rank", home: []int{100}, guest: []int{90, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formula.Calculate(tt.home, tt.guest)
+			if !errors.Is(err, ErrInvalidSide) {
+				t.Fatalf("Calculate error = %v, want %v", err, ErrInvalidSide)
+			}
+			if got.HomeStartPoints != 0 || got.GuestStartPoints != 0 {
+				t.Errorf("start points = %d-%d, want no handicap", got.HomeStartPoints, got.GuestStartPoints)
+			}
+		})
+	}
+}
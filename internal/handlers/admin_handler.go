@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/service"
+)
+
+type AdminHandler struct {
+	service service.AdminService
+}
+
+func NewAdminHandler(svc service.AdminService) *AdminHandler {
+	return &AdminHandler{service: svc}
+}
+
+type InviteAdminRequest struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type ChangeAdminRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// GetAdminsHandle handles GET /api/v1/admins
+func (h *AdminHandler) GetAdminsHandle(c *gin.Context) {
+	admins, err := h.service.GetAdminsService(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, admins)
+}
+
+// InviteAdminHandle handles POST /api/v1/admins, returning the temporary
+// password of the new account once
+func (h *AdminHandler) InviteAdminHandle(c *gin.Context) {
+	var req InviteAdminRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	credentials, err := h.service.InviteAdminService(c.Request.Context(), req.Email, req.Role, "")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, credentials)
+}
+
+// ChangeAdminRoleHandle handles PATCH /api/v1/admins/{adminId}/role
+func (h *AdminHandler) ChangeAdminRoleHandle(c *gin.Context) {
+	var req ChangeAdminRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	admin, err := h.service.ChangeAdminRoleService(c.Request.Context(), c.Param("adminId"), req.Role)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// DeactivateAdminHandle handles POST /api/v1/admins/{adminId}/deactivate
+func (h *AdminHandler) DeactivateAdminHandle(c *gin.Context) {
+	h.setActive(c, false)
+}
+
+// ActivateAdminHandle handles POST /api/v1/admins/{adminId}/activate
+func (h *AdminHandler) ActivateAdminHandle(c *gin.Context) {
+	h.setActive(c, true)
+}
+
+func (h *AdminHandler) setActive(c *gin.Context, active bool) {
+	admin, err := h.service.SetAdminActiveService(c.Request.Context(), c.Param("adminId"), active)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
+
+// ResetAdminPasswordHandle handles POST /api/v1/admins/{adminId}/reset-password,
+// returning the new temporary password once
+func (h *AdminHandler) ResetAdminPasswordHandle(c *gin.Context) {
+	credentials, err := h.service.ResetAdminPasswordService(c.Request.Context(), c.Param("adminId"), "")
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type AmendmentHandler struct {
+	service service.AmendmentService
+}
+
+func NewAmendmentHandler(svc service.AmendmentService) *AmendmentHandler {
+	return &AmendmentHandler{service: svc}
+}
+
+type AmendMatchRequest struct {
+	Reason       string  `json:"reason" binding:"required"`
+	HomeSets     []int64 `json:"home_sets"`
+	GuestSets    []int64 `json:"guest_sets"`
+	ResultType   string  `json:"result_type"`
+	WinnerTeamID *string `json:"winner_team_id"`
+}
+
+// AmendMatchHandle handles POST /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/matches/{matchId}/amend
+func (h *AmendmentHandler) AmendMatchHandle(c *gin.Context) {
+	var req AmendMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	result := models.MatchResult{
+		HomeSets:     req.HomeSets,
+		GuestSets:    req.GuestSets,
+		WinnerTeamID: req.WinnerTeamID,
+		ResultType:   req.ResultType,
+	}
+
+	amendment, err := h.service.AmendMatchService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"), c.Param("matchId"), req.Reason, result)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, amendment)
+}
+
+// GetMatchAmendmentsHandle handles GET /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/matches/{matchId}/amendments
+func (h *AmendmentHandler) GetMatchAmendmentsHandle(c *gin.Context) {
+	amendments, err := h.service.GetMatchAmendmentsService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"), c.Param("matchId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, amendments)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/service"
+)
+
+type APIKeyHandler struct {
+	service service.APIKeyService
+}
+
+func NewAPIKeyHandler(svc service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{service: svc}
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+// GetAPIKeysHandle handles GET /api/v1/api-keys
+func (h *APIKeyHandler) GetAPIKeysHandle(c *gin.Context) {
+	keys, err := h.service.GetAPIKeysService(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKeyHandle handles POST /api/v1/api-keys, returning the key once
+func (h *APIKeyHandler) CreateAPIKeyHandle(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	created, err := h.service.CreateAPIKeyService(c.Request.Context(), req.Name, req.Scopes)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// RevokeAPIKeyHandle handles DELETE /api/v1/api-keys/{keyId}
+func (h *APIKeyHandler) RevokeAPIKeyHandle(c *gin.Context) {
+	key, err := h.service.RevokeAPIKeyService(c.Request.Context(), c.Param("keyId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// GetAPIKeyUsageHandle handles GET /api/v1/api-keys/{keyId}/usage
+func (h *APIKeyHandler) GetAPIKeyUsageHandle(c *gin.Context) {
+	days := 0
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			respondError(c, apperrors.InvalidInput("days phải là số nguyên"))
+			return
+		}
+		days = parsed
+	}
+
+	usage, err := h.service.GetAPIKeyUsageService(c.Request.Context(), c.Param("keyId"), days)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
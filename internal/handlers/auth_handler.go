@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-ping-pong-app/internal/auth"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/service"
+)
+
+type AuthHandler struct {
+	service service.AuthService
+}
+
+func NewAuthHandler(svc service.AuthService) *AuthHandler {
+	return &AuthHandler{service: svc}
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// LoginHandle handles POST /api/v1/auth/login
+func (h *AuthHandler) LoginHandle(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	tokens, err := h.service.LoginService(c.Request.Context(), req.Email, req.Password)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// RefreshHandle handles POST /api/v1/auth/refresh
+func (h *AuthHandler) RefreshHandle(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	tokens, err := h.service.RefreshService(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// MeHandle handles GET /api/v1/auth/me
+func (h *AuthHandler) MeHandle(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c.Request.Context())
+	if !ok {
+		respondError(c, apperrors.Unauthorized())
+		return
+	}
+
+	c.JSON(http.StatusOK, identity)
+}
+
+// ChangePasswordHandle handles POST /api/v1/auth/password
+func (h *AuthHandler) ChangePasswordHandle(c *gin.Context) {
+	identity, ok := auth.IdentityFromContext(c.Request.Context())
+	if !ok {
+		respondError(c, apperrors.Unauthorized())
+		return
+	}
+	if identity.AdminID == "" {
+		respondError(c, apperrors.Forbidden())
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	tokens, err := h.service.ChangePasswordService(c.Request.Context(), identity.AdminID, req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type BracketHandler struct {
+	service service.BracketService
+}
+
+func NewBracketHandler(svc service.BracketService) *BracketHandler {
+	return &BracketHandler{service: svc}
+}
+
+// GetBracketsHandle handles GET /api/v1/seasons/{seasonId}/brackets
+func (h *BracketHandler) GetBracketsHandle(c *gin.Context) {
+	brackets, err := h.service.GetBracketsService(c.Request.Context(), c.Param("seasonId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, brackets)
+}
+
+// GetBracketTreeHandle handles GET /api/v1/brackets/{bracketId}
+func (h *BracketHandler) GetBracketTreeHandle(c *gin.Context) {
+	tree, err := h.service.GetBracketTreeService(c.Request.Context(), c.Param("bracketId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// CreateBracketHandle handles POST /api/v1/seasons/{seasonId}/brackets
+func (h *BracketHandler) CreateBracketHandle(c *gin.Context) {
+	var req models.CreateBracketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	tree, err := h.service.CreateBracketService(c.Request.Context(), c.Param("seasonId"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tree)
+}
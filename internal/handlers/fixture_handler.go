@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type FixtureHandler struct {
+	service service.FixtureService
+}
+
+func NewFixtureHandler(svc service.FixtureService) *FixtureHandler {
+	return &FixtureHandler{service: svc}
+}
+
+type FixtureRequest struct {
+	Round       int    `json:"round" binding:"required"`
+	HomeTeamID  string `json:"home_team_id" binding:"required"`
+	GuestTeamID string `json:"guest_team_id" binding:"required"`
+
+	LineupDeadline *time.Time `json:"lineup_deadline"`
+}
+
+// GetFixturesHandle handles GET /api/v1/seasons/{seasonId}/fixtures?round=&team_id=&status=
+func (h *FixtureHandler) GetFixturesHandle(c *gin.Context) {
+	filter := models.FixtureFilter{
+		TeamID: c.Query("team_id"),
+		Status: c.Query("status"),
+	}
+
+	if roundParam := c.Query("round"); roundParam != "" {
+		round, err := strconv.Atoi(roundParam)
+		if err != nil {
+			respondError(c, apperrors.InvalidInput("round phải là số nguyên"))
+			return
+		}
+		filter.Round = &round
+	}
+
+	fixtures, err := h.service.GetFixturesBySeasonService(c.Request.Context(), c.Param("seasonId"), filter)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fixtures)
+}
+
+// GetFixtureByIDHandle handles GET /api/v1/seasons/{seasonId}/fixtures/{fixtureId}
+func (h *FixtureHandler) GetFixtureByIDHandle(c *gin.Context) {
+	fixture, err := h.service.GetFixtureByIDService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fixture)
+}
+
+// CreateFixtureHandle handles POST /api/v1/seasons/{seasonId}/fixtures
+func (h *FixtureHandler) CreateFixtureHandle(c *gin.Context) {
+	var req FixtureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	fixture := &models.Fixture{
+		SeasonID:       c.Param("seasonId"),
+		Round:          req.Round,
+		HomeTeamID:     req.HomeTeamID,
+		GuestTeamID:    req.GuestTeamID,
+		LineupDeadline: req.LineupDeadline,
+	}
+
+	created, err := h.service.CreateFixtureService(c.Request.Context(), fixture)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateFixtureHandle handles PUT /api/v1/seasons/{seasonId}/fixtures/{fixtureId}
+func (h *FixtureHandler) UpdateFixtureHandle(c *gin.Context) {
+	var req FixtureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	fixture := &models.Fixture{
+		ID:             c.Param("fixtureId"),
+		SeasonID:       c.Param("seasonId"),
+		Round:          req.Round,
+		HomeTeamID:     req.HomeTeamID,
+		GuestTeamID:    req.GuestTeamID,
+		LineupDeadline: req.LineupDeadline,
+	}
+
+	updated, err := h.service.UpdateFixtureService(c.Request.Context(), fixture)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// CancelFixtureHandle handles POST /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/cancel
+func (h *FixtureHandler) CancelFixtureHandle(c *gin.Context) {
+	fixture, err := h.service.CancelFixtureService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fixture)
+}
@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"path/filepath"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	log "github.com/jeanphorn/log4go"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/utils"
 )
 
 // uploadAvatar handles file uploads for player avatars
@@ -78,3 +84,41 @@ func uploadAvatar(c *gin.Context) {
 		"message":    "Avatar uploaded successfully",
 	})
 }
+
+// respondError writes err as a standard AppError body. Errors that are not
+// AppErrors come from the data layer and are reported as DATABASE_ERROR.
+func respondError(c *gin.Context, err error) {
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		appErr = apperrors.DatabaseError(err)
+	}
+
+	if appErr.Cause != nil {
+		log.Error("%s: %v", appErr.Code, appErr.Cause)
+	}
+
+	c.AbortWithStatusJSON(appErr.StatusCode, appErr)
+}
+
+// parsePagination reads the page and page_size query parameters
+func parsePagination(c *gin.Context) (utils.Pagination, error) {
+	page, pageSize := 1, utils.DefaultPageSize
+
+	if value := c.Query("page"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return utils.Pagination{}, apperrors.InvalidInput("page phải là số nguyên")
+		}
+		page = parsed
+	}
+
+	if value := c.Query("page_size"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return utils.Pagination{}, apperrors.InvalidInput("page_size phải là số nguyên")
+		}
+		pageSize = parsed
+	}
+
+	return utils.NewPagination(page, pageSize), nil
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-ping-pong-app/internal/jobs"
+)
+
+type JobHandler struct {
+	scheduler *jobs.Scheduler
+}
+
+func NewJobHandler(scheduler *jobs.Scheduler) *JobHandler {
+	return &JobHandler{scheduler: scheduler}
+}
+
+// GetJobStatusesHandle handles GET /api/v1/admin/jobs
+func (h *JobHandler) GetJobStatusesHandle(c *gin.Context) {
+	c.JSON(http.StatusOK, h.scheduler.Statuses())
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type LeaderboardHandler struct {
+	service service.LeaderboardService
+}
+
+func NewLeaderboardHandler(svc service.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{service: svc}
+}
+
+// GetSeasonLeaderboardHandle handles GET /api/v1/seasons/{seasonId}/leaderboard?page=&page_size=&team_id=&rank_id=&fresh=
+func (h *LeaderboardHandler) GetSeasonLeaderboardHandle(c *gin.Context) {
+	page, err := parsePagination(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	filter := models.LeaderboardFilter{
+		SeasonID: c.Param("seasonId"),
+		TeamID:   c.Query("team_id"),
+		RankID:   c.Query("rank_id"),
+		Fresh:    c.Query("fresh") == "true",
+	}
+
+	leaderboard, err := h.service.GetSeasonLeaderboardService(c.Request.Context(), filter, page)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
+
+// GetRoundLeaderboardHandle handles GET /api/v1/seasons/{seasonId}/rounds/{round}/leaderboard?page=&page_size=&team_id=&rank_id=
+func (h *LeaderboardHandler) GetRoundLeaderboardHandle(c *gin.Context) {
+	round, err := strconv.Atoi(c.Param("round"))
+	if err != nil {
+		respondError(c, apperrors.InvalidInput("round phải là số nguyên"))
+		return
+	}
+
+	page, err := parsePagination(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	filter := models.RoundLeaderboardFilter{
+		SeasonID:    c.Param("seasonId"),
+		RoundNumber: round,
+		TeamID:      c.Query("team_id"),
+		RankID:      c.Query("rank_id"),
+	}
+
+	leaderboard, err := h.service.GetRoundLeaderboardService(c.Request.Context(), filter, page)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, leaderboard)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type LineupHandler struct {
+	service service.LineupService
+}
+
+func NewLineupHandler(svc service.LineupService) *LineupHandler {
+	return &LineupHandler{service: svc}
+}
+
+// GetLineupsHandle handles GET /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/lineups
+func (h *LineupHandler) GetLineupsHandle(c *gin.Context) {
+	lineups, err := h.service.GetFixtureLineupsService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, lineups)
+}
+
+// SubmitLineupHandle handles PUT /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/lineups/{teamId}
+func (h *LineupHandler) SubmitLineupHandle(c *gin.Context) {
+	var req models.SubmitLineupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	lineup := &models.Lineup{
+		FixtureID: c.Param("fixtureId"),
+		TeamID:    c.Param("teamId"),
+		Entries:   req.Entries,
+	}
+
+	saved, err := h.service.SubmitLineupService(c.Request.Context(), c.Param("seasonId"), lineup)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
+
+// LockLineupsHandle handles POST /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/lineups/lock
+func (h *LineupHandler) LockLineupsHandle(c *gin.Context) {
+	result, err := h.service.LockLineupsService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
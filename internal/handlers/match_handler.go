@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type MatchHandler struct {
+	service service.MatchService
+}
+
+func NewMatchHandler(svc service.MatchService) *MatchHandler {
+	return &MatchHandler{service: svc}
+}
+
+type RecordMatchRequest struct {
+	MatchOrder     int     `json:"match_order" binding:"required"`
+	MatchType      string  `json:"match_type" binding:"required"`
+	HomePlayer1ID  string  `json:"home_player1_id" binding:"required"`
+	HomePlayer2ID  *string `json:"home_player2_id"`
+	GuestPlayer1ID string  `json:"guest_player1_id" binding:"required"`
+	GuestPlayer2ID *string `json:"guest_player2_id"`
+	HomeSets       []int64 `json:"home_sets"`
+	GuestSets      []int64 `json:"guest_sets"`
+
+	// Non-NORMAL results name the winner; no-shows only apply to walkovers
+	ResultType      string   `json:"result_type"`
+	WinnerTeamID    *string  `json:"winner_team_id"`
+	NoShowPlayerIDs []string `json:"no_show_player_ids"`
+}
+
+// GetMatchesHandle handles GET /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/matches
+func (h *MatchHandler) GetMatchesHandle(c *gin.Context) {
+	matches, err := h.service.GetMatchesByFixtureService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, matches)
+}
+
+// GetMatchByIDHandle handles GET /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/matches/{matchId}
+func (h *MatchHandler) GetMatchByIDHandle(c *gin.Context) {
+	match, err := h.service.GetMatchByIDService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"), c.Param("matchId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
+
+// RecordMatchHandle handles POST /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/matches
+func (h *MatchHandler) RecordMatchHandle(c *gin.Context) {
+	var req RecordMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	match := &models.Match{
+		FixtureID:      c.Param("fixtureId"),
+		MatchOrder:     req.MatchOrder,
+		MatchType:      req.MatchType,
+		HomePlayer1ID:  req.HomePlayer1ID,
+		HomePlayer2ID:  req.HomePlayer2ID,
+		GuestPlayer1ID: req.GuestPlayer1ID,
+		GuestPlayer2ID: req.GuestPlayer2ID,
+		HomeSets:       req.HomeSets,
+		GuestSets:      req.GuestSets,
+		ResultType:     req.ResultType,
+		WinnerTeamID:   req.WinnerTeamID,
+	}
+
+	created, err := h.service.RecordMatchService(c.Request.Context(), c.Param("seasonId"), match, req.NoShowPlayerIDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// RecalculateFixtureHandle handles POST /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/recalculate
+func (h *MatchHandler) RecalculateFixtureHandle(c *gin.Context) {
+	fixture, err := h.service.RecalculateFixtureService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fixture)
+}
+
+// GetMatchSheetHandle handles GET /api/v1/seasons/{seasonId}/fixtures/{fixtureId}/match-sheet
+func (h *MatchHandler) GetMatchSheetHandle(c *gin.Context) {
+	sheet, err := h.service.GetMatchSheetService(c.Request.Context(), c.Param("seasonId"), c.Param("fixtureId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sheet)
+}
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-ping-pong-app/internal/auth"
+	"backend-ping-pong-app/internal/middleware"
+)
+
+const apiPrefix = "/api/v1"
+
+// routePermissions declares the permission every /api/v1 route requires,
+// keyed by method and path below /api/v1. League data stays public to read;
+// everything that changes it needs a role granting the permission.
+var routePermissions = map[string]auth.Permission{
+	// Player routes
+	"GET /players":        auth.PermissionPublic,
+	"GET /players/search": auth.PermissionPublic,
+	"POST /players":       auth.PermissionManageLeague,
+
+	// Player profile routes, players are further scoped to their own profile
+	"GET /players/:playerId":         auth.PermissionViewProfile,
+	"PUT /players/:playerId":         auth.PermissionEditProfile,
+	"PUT /players/:playerId/account": auth.PermissionManageUsers,
+
+	// Season routes
+	"GET /seasons":                    auth.PermissionPublic,
+	"GET /seasons/:seasonId":          auth.PermissionPublic,
+	"POST /seasons":                   auth.PermissionManageLeague,
+	"GET /seasons/:seasonId/settings": auth.PermissionPublic,
+	"PUT /seasons/:seasonId/settings": auth.PermissionManageLeague,
+
+	// Player-season (roster) routes
+	"GET /seasons/:seasonId/players":                          auth.PermissionPublic,
+	"POST /seasons/:seasonId/players":                         auth.PermissionManageLeague,
+	"PUT /seasons/:seasonId/players/order":                    auth.PermissionManageLeague,
+	"PATCH /seasons/:seasonId/players/:playerSeasonId/status": auth.PermissionManageLeague,
+
+	// Player-season point routes
+	"POST /player-seasons/:id/adjustments":  auth.PermissionManagePoints,
+	"GET /player-seasons/:id/point-history": auth.PermissionRead,
+
+	// Team routes
+	"GET /seasons/:seasonId/teams":            auth.PermissionPublic,
+	"GET /teams/:teamId":                      auth.PermissionPublic,
+	"POST /seasons/:seasonId/teams":           auth.PermissionManageLeague,
+	"GET /teams/:teamId/members":              auth.PermissionPublic,
+	"POST /teams/:teamId/transfer":            auth.PermissionManageLeague,
+	"GET /teams/:teamId/captains":             auth.PermissionRead,
+	"PUT /teams/:teamId/captains/:adminId":    auth.PermissionManageUsers,
+	"DELETE /teams/:teamId/captains/:adminId": auth.PermissionManageUsers,
+
+	// Fixture routes
+	"GET /seasons/:seasonId/fixtures":                    auth.PermissionPublic,
+	"GET /seasons/:seasonId/fixtures/:fixtureId":         auth.PermissionPublic,
+	"POST /seasons/:seasonId/fixtures":                   auth.PermissionManageLeague,
+	"PUT /seasons/:seasonId/fixtures/:fixtureId":         auth.PermissionManageLeague,
+	"POST /seasons/:seasonId/fixtures/:fixtureId/cancel": auth.PermissionManageLeague,
+	"POST /seasons/:seasonId/schedule/generate":          auth.PermissionManageLeague,
+
+	// Lineup routes, captains are further scoped to their own team
+	"GET /seasons/:seasonId/fixtures/:fixtureId/lineups":         auth.PermissionPublic,
+	"PUT /seasons/:seasonId/fixtures/:fixtureId/lineups/:teamId": auth.PermissionSubmitLineup,
+	"POST /seasons/:seasonId/fixtures/:fixtureId/lineups/lock":   auth.PermissionManageLineups,
+
+	// Match (rubber) routes
+	"GET /seasons/:seasonId/fixtures/:fixtureId/matches":                     auth.PermissionPublic,
+	"GET /seasons/:seasonId/fixtures/:fixtureId/matches/:matchId":            auth.PermissionPublic,
+	"POST /seasons/:seasonId/fixtures/:fixtureId/matches":                    auth.PermissionRecordResults,
+	"POST /seasons/:seasonId/fixtures/:fixtureId/recalculate":                auth.PermissionRecordResults,
+	"GET /seasons/:seasonId/fixtures/:fixtureId/match-sheet":                 auth.PermissionPublic,
+	"POST /seasons/:seasonId/fixtures/:fixtureId/matches/:matchId/amend":     auth.PermissionManagePoints,
+	"GET /seasons/:seasonId/fixtures/:fixtureId/matches/:matchId/amendments": auth.PermissionRead,
+
+	// Round routes
+	"POST /seasons/:seasonId/rounds/:round/finalize": auth.PermissionManageLeague,
+
+	// Rank routes
+	"GET /ranks":                                auth.PermissionPublic,
+	"GET /seasons/:seasonId/rank-history":       auth.PermissionPublic,
+	"POST /seasons/:seasonId/ranks/recalculate": auth.PermissionManageLeague,
+
+	// Leaderboard routes
+	"GET /seasons/:seasonId/leaderboard":               auth.PermissionPublic,
+	"GET /seasons/:seasonId/rounds/:round/leaderboard": auth.PermissionPublic,
+
+	// Standings routes
+	"GET /seasons/:seasonId/standings": auth.PermissionPublic,
+
+	// Bracket routes
+	"GET /seasons/:seasonId/brackets":  auth.PermissionPublic,
+	"POST /seasons/:seasonId/brackets": auth.PermissionManageLeague,
+	"GET /brackets/:bracketId":         auth.PermissionPublic,
+
+	// Tournament routes
+	"GET /seasons/:seasonId/tournaments":                      auth.PermissionPublic,
+	"POST /seasons/:seasonId/tournaments":                     auth.PermissionManageLeague,
+	"GET /tournaments/:tournamentId":                          auth.PermissionPublic,
+	"POST /tournaments/:tournamentId/entries":                 auth.PermissionManageLeague,
+	"POST /tournaments/:tournamentId/draw":                    auth.PermissionManageLeague,
+	"POST /tournaments/:tournamentId/matches/:matchId/result": auth.PermissionRecordResults,
+
+	// Admin routes
+	"GET /admin/jobs": auth.PermissionRead,
+
+	// Staff account routes
+	"GET /admins":                          auth.PermissionManageUsers,
+	"POST /admins":                         auth.PermissionManageUsers,
+	"PATCH /admins/:adminId/role":          auth.PermissionManageUsers,
+	"POST /admins/:adminId/deactivate":     auth.PermissionManageUsers,
+	"POST /admins/:adminId/activate":       auth.PermissionManageUsers,
+	"POST /admins/:adminId/reset-password": auth.PermissionManageUsers,
+
+	// API key routes
+	"GET /api-keys":              auth.PermissionManageUsers,
+	"POST /api-keys":             auth.PermissionManageUsers,
+	"DELETE /api-keys/:keyId":    auth.PermissionManageUsers,
+	"GET /api-keys/:keyId/usage": auth.PermissionManageUsers,
+}
+
+// routeScopes declares the routes API keys may call and the scopes allowed
+// to, keyed like routePermissions. Every other route is closed to API keys.
+var routeScopes = map[string][]auth.Scope{
+	// Seasons, to find the season of the other routes
+	"GET /seasons":           {auth.ScopeLeaderboardRead, auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId": {auth.ScopeLeaderboardRead, auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+
+	// Leaderboards, standings and ranks
+	"GET /seasons/:seasonId/leaderboard":               {auth.ScopeLeaderboardRead},
+	"GET /seasons/:seasonId/rounds/:round/leaderboard": {auth.ScopeLeaderboardRead},
+	"GET /seasons/:seasonId/standings":                 {auth.ScopeLeaderboardRead},
+	"GET /seasons/:seasonId/rank-history":              {auth.ScopeLeaderboardRead},
+	"GET /ranks":                                       {auth.ScopeLeaderboardRead},
+
+	// Teams, fixtures, lineups, rubbers and brackets
+	"GET /seasons/:seasonId/teams":                                {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /teams/:teamId":                                          {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/fixtures":                             {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/fixtures/:fixtureId":                  {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/fixtures/:fixtureId/lineups":          {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/fixtures/:fixtureId/matches":          {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/fixtures/:fixtureId/matches/:matchId": {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/fixtures/:fixtureId/match-sheet":      {auth.ScopeFixturesRead, auth.ScopeResultsWrite},
+	"GET /seasons/:seasonId/brackets":                             {auth.ScopeFixturesRead},
+	"GET /brackets/:bracketId":                                    {auth.ScopeFixturesRead},
+
+	// Rubber results, from the scoreboard device
+	"POST /seasons/:seasonId/fixtures/:fixtureId/matches": {auth.ScopeResultsWrite},
+}
+
+// apiPermissions expands routePermissions to the full route paths the
+// Authorize middleware matches against
+func apiPermissions() middleware.RoutePermissions {
+	permissions := make(middleware.RoutePermissions, len(routePermissions))
+	for route, permission := range routePermissions {
+		method, path, _ := strings.Cut(route, " ")
+		permissions[middleware.RouteKey(method, apiPrefix+path)] = permission
+	}
+	return permissions
+}
+
+// apiScopes expands routeScopes to full route paths like apiPermissions
+func apiScopes() middleware.RouteScopes {
+	scopes := make(middleware.RouteScopes, len(routeScopes))
+	for route, allowed := range routeScopes {
+		method, path, _ := strings.Cut(route, " ")
+		scopes[middleware.RouteKey(method, apiPrefix+path)] = allowed
+	}
+	return scopes
+}
+
+// checkRoutePermissions panics when a registered /api/v1 route has no declared
+// permission, so a new route cannot ship without one, or when a scoped route
+// does not exist
+func checkRoutePermissions(r *gin.Engine, permissions middleware.RoutePermissions, scopes middleware.RouteScopes) {
+	for route := range scopes {
+		if _, ok := permissions[route]; !ok {
+			panic(fmt.Sprintf("API key scopes declared for unknown route %s", route))
+		}
+	}
+
+	for _, route := range r.Routes() {
+		if !strings.HasPrefix(route.Path, apiPrefix+"/") || strings.HasPrefix(route.Path, apiPrefix+"/auth/") {
+			continue
+		}
+		if _, ok := permissions[middleware.RouteKey(route.Method, route.Path)]; !ok {
+			panic(fmt.Sprintf("no permission declared for %s %s", route.Method, route.Path))
+		}
+	}
+}
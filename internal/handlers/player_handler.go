@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	apperrors "backend-ping-pong-app/internal/errors"
 	"backend-ping-pong-app/internal/models"
 	"backend-ping-pong-app/internal/service"
 	"net/http"
@@ -10,9 +11,9 @@ import (
 
 type CreatePlayerRequest struct {
 	FullName  string  `json:"full_name" binding:"required"`
-	BirthYear *int    `json:"birth_year"binding:"required"`
+	BirthYear *int    `json:"birth_year" binding:"required"`
 	Phone     *string `json:"phone"`
-	AvatarURL *string `json:"avatar_url"binding:"required"`
+	AvatarURL *string `json:"avatar_url" binding:"required"`
 }
 
 type PlayerHandler struct {
@@ -70,3 +71,52 @@ func (h *PlayerHandler) CreatePlayerHandle(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, created)
 }
+
+// GetPlayerProfileHandle handles GET /api/v1/players/{playerId}
+func (h *PlayerHandler) GetPlayerProfileHandle(c *gin.Context) {
+	player, err := h.service.GetPlayerProfileService(c.Request.Context(), c.Param("playerId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
+
+// UpdatePlayerProfileHandle handles PUT /api/v1/players/{playerId}
+func (h *PlayerHandler) UpdatePlayerProfileHandle(c *gin.Context) {
+	var req models.PlayerProfileUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	player, err := h.service.UpdatePlayerProfileService(c.Request.Context(), c.Param("playerId"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
+
+type LinkPlayerAccountRequest struct {
+	FirebaseUID *string `json:"firebase_uid"` // null unlinks the account
+}
+
+// LinkPlayerAccountHandle handles PUT /api/v1/players/{playerId}/account
+func (h *PlayerHandler) LinkPlayerAccountHandle(c *gin.Context) {
+	var req LinkPlayerAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	player, err := h.service.LinkPlayerAccountService(c.Request.Context(), c.Param("playerId"), req.FirebaseUID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, player)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type PlayerSeasonHandler struct {
+	service service.PlayerSeasonService
+}
+
+func NewPlayerSeasonHandler(svc service.PlayerSeasonService) *PlayerSeasonHandler {
+	return &PlayerSeasonHandler{service: svc}
+}
+
+type RegisterPlayerRequest struct {
+	PlayerID          string  `json:"player_id" binding:"required"`
+	TeamID            string  `json:"team_id" binding:"required"`
+	RankID            string  `json:"rank_id" binding:"required"`
+	AccumulatedPoints float64 `json:"accumulated_points"`
+	DisplayOrder      *int    `json:"display_order"`
+}
+
+type UpdatePlayerSeasonStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type AdjustPointsRequest struct {
+	DeltaPoints float64 `json:"delta_points"`
+	Reason      string  `json:"reason" binding:"required"`
+	Source      string  `json:"source"` // ADMIN_ADJUST (default), PENALTY, BONUS
+}
+
+type ReorderRosterRequest struct {
+	PlayerSeasonIDs []string `json:"player_season_ids" binding:"required"`
+}
+
+// GetSeasonRosterHandle handles GET /api/v1/seasons/{seasonId}/players
+func (h *PlayerSeasonHandler) GetSeasonRosterHandle(c *gin.Context) {
+	roster, err := h.service.GetSeasonRosterService(c.Request.Context(), c.Param("seasonId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// RegisterPlayerHandle handles POST /api/v1/seasons/{seasonId}/players
+func (h *PlayerSeasonHandler) RegisterPlayerHandle(c *gin.Context) {
+	var req RegisterPlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	ps := &models.PlayerSeason{
+		SeasonID:          c.Param("seasonId"),
+		PlayerID:          req.PlayerID,
+		TeamID:            req.TeamID,
+		RankID:            req.RankID,
+		AccumulatedPoints: req.AccumulatedPoints,
+		DisplayOrder:      req.DisplayOrder,
+	}
+
+	created, err := h.service.RegisterPlayerService(c.Request.Context(), ps)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdatePlayerSeasonStatusHandle handles PATCH /api/v1/seasons/{seasonId}/players/{playerSeasonId}/status
+func (h *PlayerSeasonHandler) UpdatePlayerSeasonStatusHandle(c *gin.Context) {
+	var req UpdatePlayerSeasonStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	ps, err := h.service.UpdatePlayerSeasonStatusService(c.Request.Context(), c.Param("seasonId"), c.Param("playerSeasonId"), req.Status)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ps)
+}
+
+// ReorderRosterHandle handles PUT /api/v1/seasons/{seasonId}/players/order
+func (h *PlayerSeasonHandler) ReorderRosterHandle(c *gin.Context) {
+	var req ReorderRosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	roster, err := h.service.ReorderRosterService(c.Request.Context(), c.Param("seasonId"), req.PlayerSeasonIDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roster)
+}
+
+// AdjustPointsHandle handles POST /api/v1/player-seasons/{id}/adjustments
+func (h *PlayerSeasonHandler) AdjustPointsHandle(c *gin.Context) {
+	var req AdjustPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	adjustment, err := h.service.AdjustPointsService(c.Request.Context(), c.Param("id"), req.DeltaPoints, req.Reason, req.Source)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, adjustment)
+}
+
+// GetPointHistoryHandle handles GET /api/v1/player-seasons/{id}/point-history
+func (h *PlayerSeasonHandler) GetPointHistoryHandle(c *gin.Context) {
+	history, err := h.service.GetPointHistoryService(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
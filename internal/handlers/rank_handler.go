@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-ping-pong-app/internal/service"
+)
+
+type RankHandler struct {
+	service service.RankService
+}
+
+func NewRankHandler(svc service.RankService) *RankHandler {
+	return &RankHandler{service: svc}
+}
+
+// GetRanksHandle handles GET /api/v1/ranks
+func (h *RankHandler) GetRanksHandle(c *gin.Context) {
+	ranks, err := h.service.GetAllRanksService(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ranks)
+}
+
+// GetRankHistoryHandle handles GET /api/v1/seasons/{seasonId}/rank-history?player_id=
+func (h *RankHandler) GetRankHistoryHandle(c *gin.Context) {
+	history, err := h.service.GetRankHistoryService(c.Request.Context(), c.Param("seasonId"), c.Query("player_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// RecalculateRanksHandle handles POST /api/v1/seasons/{seasonId}/ranks/recalculate
+func (h *RankHandler) RecalculateRanksHandle(c *gin.Context) {
+	changes, err := h.service.RecalculateRanksService(c.Request.Context(), c.Param("seasonId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/service"
+)
+
+type RoundHandler struct {
+	service service.RoundService
+}
+
+func NewRoundHandler(svc service.RoundService) *RoundHandler {
+	return &RoundHandler{service: svc}
+}
+
+// FinalizeRoundHandle handles POST /api/v1/seasons/{seasonId}/rounds/{round}/finalize?dry_run=true
+func (h *RoundHandler) FinalizeRoundHandle(c *gin.Context) {
+	round, err := strconv.Atoi(c.Param("round"))
+	if err != nil {
+		respondError(c, apperrors.InvalidInput("round phải là số nguyên"))
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	result, err := h.service.FinalizeRoundService(c.Request.Context(), c.Param("seasonId"), round, dryRun)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
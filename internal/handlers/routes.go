@@ -3,30 +3,152 @@ package handlers
 import (
 	"github.com/gin-gonic/gin"
 
+	"backend-ping-pong-app/internal/jobs"
+	"backend-ping-pong-app/internal/middleware"
 	"backend-ping-pong-app/internal/service"
 )
 
-func RegisterRoutes(r *gin.Engine, svc *service.Service) {
+func RegisterRoutes(r *gin.Engine, svc *service.Service, scheduler *jobs.Scheduler) {
 	playerHandler := NewPlayerHandler(svc.Player)
 	seasonHandler := NewSeasonHandler(svc.Season)
 	teamHandler := NewTeamHandler(svc.Team)
+	transferHandler := NewTransferHandler(svc.Transfer)
+	fixtureHandler := NewFixtureHandler(svc.Fixture)
+	scheduleHandler := NewScheduleHandler(svc.Schedule)
+	lineupHandler := NewLineupHandler(svc.Lineup)
+	matchHandler := NewMatchHandler(svc.Match)
+	amendmentHandler := NewAmendmentHandler(svc.Amendment)
+	roundHandler := NewRoundHandler(svc.Round)
+	rankHandler := NewRankHandler(svc.Rank)
+	leaderboardHandler := NewLeaderboardHandler(svc.Leaderboard)
+	standingsHandler := NewStandingsHandler(svc.Standings)
+	bracketHandler := NewBracketHandler(svc.Bracket)
+	tournamentHandler := NewTournamentHandler(svc.Tournament)
+	jobHandler := NewJobHandler(scheduler)
+	playerSeasonHandler := NewPlayerSeasonHandler(svc.PlayerSeason)
+	authHandler := NewAuthHandler(svc.Auth)
+	adminHandler := NewAdminHandler(svc.Admin)
+	apiKeyHandler := NewAPIKeyHandler(svc.APIKey)
 
-	v1 := r.Group("/api/v1")
+	authenticate := middleware.Authenticate(svc.Auth)
+
+	// Auth routes are open so admins can sign in; players sign in to the
+	// mobile app with Firebase and send its ID token as Bearer token
+	authRoutes := r.Group("/api/v1/auth")
+	{
+		authRoutes.POST("/login", authHandler.LoginHandle)
+		authRoutes.POST("/refresh", authHandler.RefreshHandle)
+		authRoutes.GET("/me", authenticate, middleware.RequireAuth(), authHandler.MeHandle)
+		authRoutes.POST("/password", authenticate, middleware.RequireAuth(), authHandler.ChangePasswordHandle)
+	}
+
+	// Every other route requires the permission declared in routePermissions,
+	// or for API keys one of the scopes declared in routeScopes
+	permissions, scopes := apiPermissions(), apiScopes()
+	v1 := r.Group("/api/v1", authenticate, middleware.AuthenticateAPIKey(svc.APIKey), middleware.Authorize(permissions, scopes))
 	{
 		// Player routes
 		v1.GET("/players", playerHandler.GetPlayersHandle)
 		v1.GET("/players/search", playerHandler.SearchPlayersHandle)
 		v1.POST("/players", playerHandler.CreatePlayerHandle)
+		v1.GET("/players/:playerId", playerHandler.GetPlayerProfileHandle)
+		v1.PUT("/players/:playerId", playerHandler.UpdatePlayerProfileHandle)
+		v1.PUT("/players/:playerId/account", playerHandler.LinkPlayerAccountHandle)
 
 		// Season routes
 		v1.GET("/seasons", seasonHandler.GetSeasonsHandle)
 		v1.GET("/seasons/:seasonId", seasonHandler.GetSeasonByIDHandle)
 		v1.POST("/seasons", seasonHandler.CreateSeasonHandle)
+		v1.GET("/seasons/:seasonId/settings", seasonHandler.GetSeasonSettingsHandle)
+		v1.PUT("/seasons/:seasonId/settings", seasonHandler.UpdateSeasonSettingsHandle)
+
+		// Player-season (roster) routes
+		v1.GET("/seasons/:seasonId/players", playerSeasonHandler.GetSeasonRosterHandle)
+		v1.POST("/seasons/:seasonId/players", playerSeasonHandler.RegisterPlayerHandle)
+		v1.PUT("/seasons/:seasonId/players/order", playerSeasonHandler.ReorderRosterHandle)
+		v1.PATCH("/seasons/:seasonId/players/:playerSeasonId/status", playerSeasonHandler.UpdatePlayerSeasonStatusHandle)
+
+		// Player-season point routes
+		v1.POST("/player-seasons/:id/adjustments", playerSeasonHandler.AdjustPointsHandle)
+		v1.GET("/player-seasons/:id/point-history", playerSeasonHandler.GetPointHistoryHandle)
 
 		// Team routes
 		v1.GET("/seasons/:seasonId/teams", teamHandler.GetTeamsBySeasonHandle)
 		v1.GET("/teams/:teamId", teamHandler.GetTeamByIDHandle)
 		v1.POST("/seasons/:seasonId/teams", teamHandler.CreateTeamHandle)
 		v1.GET("/teams/:teamId/members", teamHandler.GetTeamMembersHandle)
+		v1.POST("/teams/:teamId/transfer", transferHandler.TransferPlayerHandle)
+		v1.GET("/teams/:teamId/captains", teamHandler.GetTeamCaptainsHandle)
+		v1.PUT("/teams/:teamId/captains/:adminId", teamHandler.AddTeamCaptainHandle)
+		v1.DELETE("/teams/:teamId/captains/:adminId", teamHandler.RemoveTeamCaptainHandle)
+
+		// Fixture routes
+		v1.GET("/seasons/:seasonId/fixtures", fixtureHandler.GetFixturesHandle)
+		v1.GET("/seasons/:seasonId/fixtures/:fixtureId", fixtureHandler.GetFixtureByIDHandle)
+		v1.POST("/seasons/:seasonId/fixtures", fixtureHandler.CreateFixtureHandle)
+		v1.PUT("/seasons/:seasonId/fixtures/:fixtureId", fixtureHandler.UpdateFixtureHandle)
+		v1.POST("/seasons/:seasonId/fixtures/:fixtureId/cancel", fixtureHandler.CancelFixtureHandle)
+		v1.POST("/seasons/:seasonId/schedule/generate", scheduleHandler.GenerateScheduleHandle)
+
+		// Lineup routes
+		v1.GET("/seasons/:seasonId/fixtures/:fixtureId/lineups", lineupHandler.GetLineupsHandle)
+		v1.PUT("/seasons/:seasonId/fixtures/:fixtureId/lineups/:teamId", lineupHandler.SubmitLineupHandle)
+		v1.POST("/seasons/:seasonId/fixtures/:fixtureId/lineups/lock", lineupHandler.LockLineupsHandle)
+
+		// Match (rubber) routes
+		v1.GET("/seasons/:seasonId/fixtures/:fixtureId/matches", matchHandler.GetMatchesHandle)
+		v1.GET("/seasons/:seasonId/fixtures/:fixtureId/matches/:matchId", matchHandler.GetMatchByIDHandle)
+		v1.POST("/seasons/:seasonId/fixtures/:fixtureId/matches", matchHandler.RecordMatchHandle)
+		v1.POST("/seasons/:seasonId/fixtures/:fixtureId/recalculate", matchHandler.RecalculateFixtureHandle)
+		v1.GET("/seasons/:seasonId/fixtures/:fixtureId/match-sheet", matchHandler.GetMatchSheetHandle)
+		v1.POST("/seasons/:seasonId/fixtures/:fixtureId/matches/:matchId/amend", amendmentHandler.AmendMatchHandle)
+		v1.GET("/seasons/:seasonId/fixtures/:fixtureId/matches/:matchId/amendments", amendmentHandler.GetMatchAmendmentsHandle)
+
+		// Round routes
+		v1.POST("/seasons/:seasonId/rounds/:round/finalize", roundHandler.FinalizeRoundHandle)
+
+		// Rank routes
+		v1.GET("/ranks", rankHandler.GetRanksHandle)
+		v1.GET("/seasons/:seasonId/rank-history", rankHandler.GetRankHistoryHandle)
+		v1.POST("/seasons/:seasonId/ranks/recalculate", rankHandler.RecalculateRanksHandle)
+
+		// Leaderboard routes
+		v1.GET("/seasons/:seasonId/leaderboard", leaderboardHandler.GetSeasonLeaderboardHandle)
+		v1.GET("/seasons/:seasonId/rounds/:round/leaderboard", leaderboardHandler.GetRoundLeaderboardHandle)
+
+		// Standings routes
+		v1.GET("/seasons/:seasonId/standings", standingsHandler.GetSeasonStandingsHandle)
+
+		// Bracket routes
+		v1.GET("/seasons/:seasonId/brackets", bracketHandler.GetBracketsHandle)
+		v1.POST("/seasons/:seasonId/brackets", bracketHandler.CreateBracketHandle)
+		v1.GET("/brackets/:bracketId", bracketHandler.GetBracketTreeHandle)
+
+		// Tournament routes
+		v1.GET("/seasons/:seasonId/tournaments", tournamentHandler.GetTournamentsHandle)
+		v1.POST("/seasons/:seasonId/tournaments", tournamentHandler.CreateTournamentHandle)
+		v1.GET("/tournaments/:tournamentId", tournamentHandler.GetTournamentHandle)
+		v1.POST("/tournaments/:tournamentId/entries", tournamentHandler.AddEntriesHandle)
+		v1.POST("/tournaments/:tournamentId/draw", tournamentHandler.DrawGroupsHandle)
+		v1.POST("/tournaments/:tournamentId/matches/:matchId/result", tournamentHandler.RecordResultHandle)
+
+		// Admin routes
+		v1.GET("/admin/jobs", jobHandler.GetJobStatusesHandle)
+
+		// Staff account routes
+		v1.GET("/admins", adminHandler.GetAdminsHandle)
+		v1.POST("/admins", adminHandler.InviteAdminHandle)
+		v1.PATCH("/admins/:adminId/role", adminHandler.ChangeAdminRoleHandle)
+		v1.POST("/admins/:adminId/deactivate", adminHandler.DeactivateAdminHandle)
+		v1.POST("/admins/:adminId/activate", adminHandler.ActivateAdminHandle)
+		v1.POST("/admins/:adminId/reset-password", adminHandler.ResetAdminPasswordHandle)
+
+		// API key routes
+		v1.GET("/api-keys", apiKeyHandler.GetAPIKeysHandle)
+		v1.POST("/api-keys", apiKeyHandler.CreateAPIKeyHandle)
+		v1.DELETE("/api-keys/:keyId", apiKeyHandler.RevokeAPIKeyHandle)
+		v1.GET("/api-keys/:keyId/usage", apiKeyHandler.GetAPIKeyUsageHandle)
 	}
+
+	checkRoutePermissions(r, permissions, scopes)
 }
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type ScheduleHandler struct {
+	service service.ScheduleService
+}
+
+func NewScheduleHandler(svc service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{service: svc}
+}
+
+// GenerateScheduleHandle handles POST /api/v1/seasons/{seasonId}/schedule/generate?preview=true
+func (h *ScheduleHandler) GenerateScheduleHandle(c *gin.Context) {
+	var req models.ScheduleRequest
+	// An empty body generates a single round-robin from round 1
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, apperrors.InvalidInput(err.Error()))
+			return
+		}
+	}
+
+	preview := c.Query("preview") == "true"
+
+	schedule, err := h.service.GenerateScheduleService(c.Request.Context(), c.Param("seasonId"), req, preview)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if preview {
+		status = http.StatusOK
+	}
+	c.JSON(status, schedule)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	apperrors "backend-ping-pong-app/internal/errors"
 	"backend-ping-pong-app/internal/models"
 	"backend-ping-pong-app/internal/service"
 )
@@ -67,7 +68,7 @@ func (h *SeasonHandler) CreateSeasonHandle(c *gin.Context) {
 	}
 
 	if season.Status == "" {
-		season.Status = "UPCOMING"
+		season.Status = models.SeasonStatusUpcoming
 	}
 
 	created, err := h.service.CreateSeason(c.Request.Context(), season)
@@ -78,3 +79,31 @@ func (h *SeasonHandler) CreateSeasonHandle(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, created)
 }
+
+// GetSeasonSettingsHandle handles GET /api/v1/seasons/{seasonId}/settings
+func (h *SeasonHandler) GetSeasonSettingsHandle(c *gin.Context) {
+	settings, err := h.service.GetSeasonSettings(c.Request.Context(), c.Param("seasonId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSeasonSettingsHandle handles PUT /api/v1/seasons/{seasonId}/settings
+func (h *SeasonHandler) UpdateSeasonSettingsHandle(c *gin.Context) {
+	var req models.SeasonSettingsUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	updated, err := h.service.UpdateSeasonSettings(c.Request.Context(), c.Param("seasonId"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"backend-ping-pong-app/internal/service"
+)
+
+type StandingsHandler struct {
+	service service.StandingsService
+}
+
+func NewStandingsHandler(svc service.StandingsService) *StandingsHandler {
+	return &StandingsHandler{service: svc}
+}
+
+// GetSeasonStandingsHandle handles GET /api/v1/seasons/{seasonId}/standings
+func (h *StandingsHandler) GetSeasonStandingsHandle(c *gin.Context) {
+	standings, err := h.service.GetSeasonStandingsService(c.Request.Context(), c.Param("seasonId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, standings)
+}
@@ -94,3 +94,36 @@ func (h *TeamHandler) GetTeamMembersHandle(c *gin.Context) {
 
 	c.JSON(http.StatusOK, members)
 }
+
+// GetTeamCaptainsHandle handles GET /api/v1/teams/{teamId}/captains
+func (h *TeamHandler) GetTeamCaptainsHandle(c *gin.Context) {
+	captains, err := h.service.GetTeamCaptainsService(c.Request.Context(), c.Param("teamId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, captains)
+}
+
+// AddTeamCaptainHandle handles PUT /api/v1/teams/{teamId}/captains/{adminId}
+func (h *TeamHandler) AddTeamCaptainHandle(c *gin.Context) {
+	captains, err := h.service.AddTeamCaptainService(c.Request.Context(), c.Param("teamId"), c.Param("adminId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, captains)
+}
+
+// RemoveTeamCaptainHandle handles DELETE /api/v1/teams/{teamId}/captains/{adminId}
+func (h *TeamHandler) RemoveTeamCaptainHandle(c *gin.Context) {
+	captains, err := h.service.RemoveTeamCaptainService(c.Request.Context(), c.Param("teamId"), c.Param("adminId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, captains)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type TournamentHandler struct {
+	service service.TournamentService
+}
+
+func NewTournamentHandler(svc service.TournamentService) *TournamentHandler {
+	return &TournamentHandler{service: svc}
+}
+
+// GetTournamentsHandle handles GET /api/v1/seasons/{seasonId}/tournaments
+func (h *TournamentHandler) GetTournamentsHandle(c *gin.Context) {
+	tournaments, err := h.service.GetTournamentsService(c.Request.Context(), c.Param("seasonId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournaments)
+}
+
+// GetTournamentHandle handles GET /api/v1/tournaments/{tournamentId}
+func (h *TournamentHandler) GetTournamentHandle(c *gin.Context) {
+	tournament, err := h.service.GetTournamentService(c.Request.Context(), c.Param("tournamentId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+// CreateTournamentHandle handles POST /api/v1/seasons/{seasonId}/tournaments
+func (h *TournamentHandler) CreateTournamentHandle(c *gin.Context) {
+	var req models.CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	tournament, err := h.service.CreateTournamentService(c.Request.Context(), c.Param("seasonId"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, tournament)
+}
+
+// AddEntriesHandle handles POST /api/v1/tournaments/{tournamentId}/entries
+func (h *TournamentHandler) AddEntriesHandle(c *gin.Context) {
+	var req models.TournamentEntriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	entries, err := h.service.AddEntriesService(c.Request.Context(), c.Param("tournamentId"), req.PlayerIDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entries)
+}
+
+// DrawGroupsHandle handles POST /api/v1/tournaments/{tournamentId}/draw
+func (h *TournamentHandler) DrawGroupsHandle(c *gin.Context) {
+	tournament, err := h.service.DrawGroupsService(c.Request.Context(), c.Param("tournamentId"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tournament)
+}
+
+// RecordResultHandle handles POST /api/v1/tournaments/{tournamentId}/matches/{matchId}/result
+func (h *TournamentHandler) RecordResultHandle(c *gin.Context) {
+	var req models.TournamentResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	match, err := h.service.RecordResultService(c.Request.Context(), c.Param("tournamentId"), c.Param("matchId"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+type TransferHandler struct {
+	service service.TransferService
+}
+
+func NewTransferHandler(svc service.TransferService) *TransferHandler {
+	return &TransferHandler{service: svc}
+}
+
+// TransferPlayerHandle handles POST /api/v1/teams/{teamId}/transfer
+func (h *TransferHandler) TransferPlayerHandle(c *gin.Context) {
+	var req models.TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.InvalidInput(err.Error()))
+		return
+	}
+
+	transfer, err := h.service.TransferPlayerService(c.Request.Context(), c.Param("teamId"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, transfer)
+}
@@ -1 +1,168 @@
 package jobs
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/jeanphorn/log4go"
+)
+
+// Job is a unit of background work run periodically by the Scheduler
+type Job interface {
+	Name() string
+	Interval() time.Duration
+	Run(ctx context.Context) error
+}
+
+// Status is the last known state of a job, exposed on the admin endpoint
+type Status struct {
+	Name           string     `json:"name"`
+	Interval       string     `json:"interval"`
+	Running        bool       `json:"running"`
+	RunCount       int        `json:"run_count"`
+	LastRunAt      *time.Time `json:"last_run_at"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastError      *string    `json:"last_error"`
+	LastSkippedAt  *time.Time `json:"last_skipped_at,omitempty"` // another replica held the lock
+}
+
+// Scheduler runs every registered job on its interval. Each run takes a
+// Postgres advisory lock named after the job so that only one replica runs a
+// given job at a time; replicas that miss the lock skip that tick.
+type Scheduler struct {
+	db   *sql.DB
+	jobs []Job
+
+	mu       sync.RWMutex
+	statuses map[string]*Status
+}
+
+func NewScheduler(db *sql.DB) *Scheduler {
+	return &Scheduler{
+		db:       db,
+		statuses: make(map[string]*Status),
+	}
+}
+
+// Register adds a job; it must be called before Start
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+	s.statuses[job.Name()] = &Status{
+		Name:     job.Name(),
+		Interval: job.Interval().String(),
+	}
+}
+
+// Start runs every job once immediately and then on its interval until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+	log.Info("Started %d background jobs", len(s.jobs))
+}
+
+// Statuses returns a snapshot of every job's status ordered by name
+func (s *Scheduler) Statuses() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval())
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	ctx, cancel := context.WithTimeout(ctx, job.Interval())
+	defer cancel()
+
+	// Advisory locks belong to a session, so pin one connection for the run
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		s.finish(job, time.Now(), err)
+		return
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, lockName(job)).Scan(&locked); err != nil {
+		s.finish(job, time.Now(), err)
+		return
+	}
+	if !locked {
+		s.skip(job)
+		return
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even after a timeout
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, lockName(job)); err != nil {
+			log.Error("Job %s: failed to release advisory lock: %v", job.Name(), err)
+		}
+	}()
+
+	started := time.Now()
+	s.setRunning(job, true)
+	err = job.Run(ctx)
+	s.finish(job, started, err)
+}
+
+func (s *Scheduler) setRunning(job Job, running bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[job.Name()].Running = running
+}
+
+func (s *Scheduler) skip(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.statuses[job.Name()].LastSkippedAt = &now
+	log.Debug("Job %s skipped: lock held by another instance", job.Name())
+}
+
+func (s *Scheduler) finish(job Job, started time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.statuses[job.Name()]
+	status.Running = false
+	status.RunCount++
+	status.LastRunAt = &started
+	status.LastDurationMs = time.Since(started).Milliseconds()
+	status.LastError = nil
+
+	if err != nil {
+		msg := err.Error()
+		status.LastError = &msg
+		log.Error("Job %s failed: %v", job.Name(), err)
+		return
+	}
+	log.Debug("Job %s finished in %dms", job.Name(), status.LastDurationMs)
+}
+
+func lockName(job Job) string {
+	return "jobs:" + job.Name()
+}
@@ -1 +1,30 @@
 package jobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// LeaderboardRefreshJob refreshes mat_season_leaderboard without blocking readers
+type LeaderboardRefreshJob struct {
+	db       *sql.DB
+	interval time.Duration
+}
+
+func NewLeaderboardRefreshJob(db *sql.DB, interval time.Duration) *LeaderboardRefreshJob {
+	return &LeaderboardRefreshJob{db: db, interval: interval}
+}
+
+func (j *LeaderboardRefreshJob) Name() string {
+	return "leaderboard_refresh"
+}
+
+func (j *LeaderboardRefreshJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *LeaderboardRefreshJob) Run(ctx context.Context) error {
+	_, err := j.db.ExecContext(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY mat_season_leaderboard`)
+	return err
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	log "github.com/jeanphorn/log4go"
+
+	"backend-ping-pong-app/internal/service"
+)
+
+// LineupLockJob locks the lineups of fixtures whose lineup deadline has
+// passed, which pre-fills their rubbers
+type LineupLockJob struct {
+	lineups  service.LineupService
+	interval time.Duration
+}
+
+func NewLineupLockJob(lineups service.LineupService, interval time.Duration) *LineupLockJob {
+	return &LineupLockJob{lineups: lineups, interval: interval}
+}
+
+func (j *LineupLockJob) Name() string {
+	return "lineup_lock"
+}
+
+func (j *LineupLockJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *LineupLockJob) Run(ctx context.Context) error {
+	locked, err := j.lineups.LockDueLineupsService(ctx, time.Now())
+	if locked > 0 {
+		log.Info("Locked lineups of %d fixtures", locked)
+	}
+	return err
+}
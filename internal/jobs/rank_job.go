@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	log "github.com/jeanphorn/log4go"
+
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/service"
+)
+
+// RankRecalcJob re-evaluates player ranks of every active season
+type RankRecalcJob struct {
+	seasons  service.SeasonService
+	ranks    service.RankService
+	interval time.Duration
+}
+
+func NewRankRecalcJob(seasons service.SeasonService, ranks service.RankService, interval time.Duration) *RankRecalcJob {
+	return &RankRecalcJob{seasons: seasons, ranks: ranks, interval: interval}
+}
+
+func (j *RankRecalcJob) Name() string {
+	return "rank_recalc"
+}
+
+func (j *RankRecalcJob) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *RankRecalcJob) Run(ctx context.Context) error {
+	seasons, err := j.seasons.GetAllSeasons(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, season := range seasons {
+		if season.Status != models.SeasonStatusActive {
+			continue
+		}
+
+		changes, err := j.ranks.RecalculateRanksService(ctx, season.ID)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			log.Info("Season %s: %d rank changes", season.ID, len(changes))
+		}
+	}
+
+	return nil
+}
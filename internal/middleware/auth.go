@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/jeanphorn/log4go"
+
+	"backend-ping-pong-app/internal/auth"
+	apperrors "backend-ping-pong-app/internal/errors"
+)
+
+// Authenticator resolves the caller identity behind an access token
+type Authenticator interface {
+	AuthenticateService(ctx context.Context, accessToken string) (*auth.Identity, error)
+}
+
+// Authenticate reads the Bearer token of the request and stores the caller
+// identity in the request context. Requests without a token go on
+// anonymously; a token that does not verify is rejected.
+func Authenticate(authenticator Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || strings.TrimSpace(token) == "" {
+			abortWithError(c, apperrors.Unauthorized())
+			return
+		}
+
+		identity, err := authenticator.AuthenticateService(c.Request.Context(), strings.TrimSpace(token))
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// APIKeyHeader carries the API key of an integration
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator resolves the integration behind an API key
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKeyService(ctx context.Context, key string) (*auth.Identity, error)
+}
+
+// AuthenticateAPIKey reads the X-API-Key header and stores the integration
+// identity in the request context. It runs after Authenticate; sending both
+// a token and a key is rejected, as is a key that is unknown or revoked.
+func AuthenticateAPIKey(authenticator APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(APIKeyHeader))
+		if key == "" {
+			c.Next()
+			return
+		}
+		if _, ok := auth.IdentityFromContext(c.Request.Context()); ok {
+			abortWithError(c, apperrors.Unauthorized())
+			return
+		}
+
+		identity, err := authenticator.AuthenticateAPIKeyService(c.Request.Context(), key)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// RequireAuth rejects anonymous requests with UNAUTHORIZED
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := auth.IdentityFromContext(c.Request.Context()); !ok {
+			abortWithError(c, apperrors.Unauthorized())
+			return
+		}
+		c.Next()
+	}
+}
+
+// RoutePermissions maps a route, as built by RouteKey, to the permission it
+// requires
+type RoutePermissions map[string]auth.Permission
+
+// RouteScopes maps a route, as built by RouteKey, to the API key scopes that
+// may call it
+type RouteScopes map[string][]auth.Scope
+
+// RouteKey is the RoutePermissions and RouteScopes key of a method and a full
+// route path
+func RouteKey(method, fullPath string) string {
+	return method + " " + fullPath
+}
+
+// Authorize enforces the permission declared for the matched route. Public
+// routes pass through, anonymous callers of other routes get UNAUTHORIZED,
+// admins still on a temporary password get PASSWORD_CHANGE_REQUIRED and
+// callers whose role lacks the permission get FORBIDDEN. API keys only reach
+// the routes scopes declares for one of their scopes. A route missing from
+// permissions is refused rather than left open.
+func Authorize(permissions RoutePermissions, scopes RouteScopes) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := RouteKey(c.Request.Method, c.FullPath())
+		permission, ok := permissions[route]
+		if !ok {
+			abortWithError(c, apperrors.Forbidden())
+			return
+		}
+
+		identity, ok := auth.IdentityFromContext(c.Request.Context())
+		if ok && identity.APIKeyID != "" {
+			if !hasAnyScope(identity, scopes[route]) {
+				abortWithError(c, apperrors.Forbidden().WithDetails(map[string]interface{}{
+					"scopes": scopes[route],
+				}))
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if permission == auth.PermissionPublic {
+			c.Next()
+			return
+		}
+
+		if !ok {
+			abortWithError(c, apperrors.Unauthorized())
+			return
+		}
+		if identity.MustChangePassword {
+			abortWithError(c, apperrors.PasswordChangeRequired())
+			return
+		}
+		if !identity.Can(permission) {
+			abortWithError(c, apperrors.Forbidden().WithDetails(map[string]interface{}{
+				"role":       identity.Role,
+				"permission": permission,
+			}))
+			return
+		}
+		c.Next()
+	}
+}
+
+func hasAnyScope(identity *auth.Identity, scopes []auth.Scope) bool {
+	for _, scope := range scopes {
+		if identity.HasScope(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// abortWithError writes err as a standard AppError body, like the handlers do
+func abortWithError(c *gin.Context, err error) {
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		appErr = apperrors.DatabaseError(err)
+	}
+
+	if appErr.Cause != nil {
+		log.Error("%s: %v", appErr.Code, appErr.Cause)
+	}
+
+	c.AbortWithStatusJSON(appErr.StatusCode, appErr)
+}
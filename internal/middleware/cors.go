@@ -22,7 +22,7 @@ func CORS() gin.HandlerFunc {
 
 		c.Writer.Header().Set(
 			"Access-Control-Allow-Headers",
-			"Origin, Content-Type, Authorization",
+			"Origin, Content-Type, Authorization, X-API-Key",
 		)
 
 		c.Writer.Header().Set(
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Admin roles. A CAPTAIN only manages the lineups of the teams they captain.
+const (
+	AdminRoleAdmin     = "ADMIN"
+	AdminRoleModerator = "MODERATOR"
+	AdminRoleCaptain   = "CAPTAIN"
+	AdminRoleViewer    = "VIEWER"
+)
+
+// IsAdminRole reports whether role is one of the admin roles
+func IsAdminRole(role string) bool {
+	switch role {
+	case AdminRoleAdmin, AdminRoleModerator, AdminRoleCaptain, AdminRoleViewer:
+		return true
+	}
+	return false
+}
+
+// Admin is a back office account that signs in with email and password
+type Admin struct {
+	ID                 string    `json:"id"`
+	Email              string    `json:"email"`
+	PasswordHash       string    `json:"-"`
+	Role               string    `json:"role"` // ADMIN, MODERATOR, CAPTAIN, VIEWER
+	IsActive           bool      `json:"is_active"`
+	MustChangePassword bool      `json:"must_change_password"`
+	PasswordChangedAt  time.Time `json:"password_changed_at"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// AdminCredentials is an account with the temporary password it was just
+// invited or reset with. The password is shown once and must be changed at
+// the first sign-in.
+type AdminCredentials struct {
+	Admin             Admin  `json:"admin"`
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+// AuthTokens is the result of POST /auth/login and POST /auth/refresh
+type AuthTokens struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	Admin            Admin     `json:"admin"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// APIKey is a scoped key of a third-party integration. The key itself is
+// only shown when created.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  *string    `json:"created_by,omitempty"`
+	UsageCount int64      `json:"usage_count"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APIKeyCreated is the result of POST /api-keys, the only time the key is
+// returned
+type APIKeyCreated struct {
+	APIKey APIKey `json:"api_key"`
+	Key    string `json:"key"`
+}
+
+// APIKeyUsage is the number of requests made with a key on a day
+type APIKeyUsage struct {
+	Date         time.Time `json:"date"`
+	RequestCount int64     `json:"request_count"`
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Bracket statuses
+const (
+	BracketStatusActive    = "ACTIVE"
+	BracketStatusCompleted = "COMPLETED"
+)
+
+// Bracket seed sources
+const (
+	SeedSourceStandings   = "STANDINGS"   // team standings position
+	SeedSourceLeaderboard = "LEADERBOARD" // average points of the team's active players
+)
+
+// Bracket is a single elimination playoff between the teams of a season
+type Bracket struct {
+	ID         string    `json:"id"`
+	SeasonID   string    `json:"season_id"`
+	Name       string    `json:"name"`
+	Size       int       `json:"size"`    // power of two
+	Entries    int       `json:"entries"` // teams in the bracket, the rest of size are byes
+	SeedSource string    `json:"seed_source"`
+	ThirdPlace bool      `json:"third_place"`
+	StartRound int       `json:"start_round"` // fixture round of the first bracket round
+	Status     string    `json:"status"`      // ACTIVE, COMPLETED
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// BracketMatch is one node of the bracket tree. Its fixture is created once
+// both teams are known; a match against a bye has a winner but no fixture.
+type BracketMatch struct {
+	ID            string    `json:"id"`
+	BracketID     string    `json:"bracket_id"`
+	Round         int       `json:"round"`
+	Position      int       `json:"position"`
+	ThirdPlace    bool      `json:"third_place"`
+	HomeSeed      *int      `json:"home_seed"`
+	GuestSeed     *int      `json:"guest_seed"`
+	HomeTeamID    *string   `json:"home_team_id"`
+	HomeTeamName  *string   `json:"home_team_name"`
+	GuestTeamID   *string   `json:"guest_team_id"`
+	GuestTeamName *string   `json:"guest_team_name"`
+	FixtureID     *string   `json:"fixture_id"`
+	WinnerTeamID  *string   `json:"winner_team_id"`
+	NextMatchID   *string   `json:"next_match_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CreateBracketRequest is the body of POST /seasons/{seasonId}/brackets
+type CreateBracketRequest struct {
+	Name       string `json:"name" binding:"required"`
+	Entries    int    `json:"entries"`     // top N teams, defaults to every team
+	SeedSource string `json:"seed_source"` // STANDINGS (default) or LEADERBOARD
+	ThirdPlace bool   `json:"third_place"`
+	StartRound int    `json:"start_round"` // defaults to the round after the last fixture
+}
+
+// BracketRound groups the matches of one bracket round, ordered by position
+type BracketRound struct {
+	Round   int            `json:"round"`
+	Matches []BracketMatch `json:"matches"`
+}
+
+// BracketTree for GET /brackets/{bracketId}. The final is the last round;
+// the third place match, if any, is listed separately.
+type BracketTree struct {
+	Bracket
+	Rounds          []BracketRound `json:"rounds"`
+	ThirdPlaceMatch *BracketMatch  `json:"third_place_match"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Fixture statuses
+const (
+	FixtureStatusScheduled = "SCHEDULED"
+	FixtureStatusOngoing   = "ONGOING"
+	FixtureStatusCompleted = "COMPLETED"
+	FixtureStatusCancelled = "CANCELLED"
+)
+
+// Fixture represents a club-vs-club tie in a season round
+type Fixture struct {
+	ID          string `json:"id"`
+	SeasonID    string `json:"season_id"`
+	Round       int    `json:"round"`
+	HomeTeamID  string `json:"home_team_id"`
+	GuestTeamID string `json:"guest_team_id"`
+	HomeScore   int    `json:"home_score"`
+	GuestScore  int    `json:"guest_score"`
+	Status      string `json:"status"` // SCHEDULED, ONGOING, COMPLETED, CANCELLED
+
+	// LineupDeadline is when submitted lineups lock; nil locks them manually only
+	LineupDeadline *time.Time `json:"lineup_deadline,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FixtureFilter holds the optional filters of GET /seasons/{seasonId}/fixtures
+type FixtureFilter struct {
+	Round  *int
+	TeamID string
+	Status string
+}
+
+// IsValidFixtureStatus reports whether status is a known fixture status
+func IsValidFixtureStatus(status string) bool {
+	switch status {
+	case FixtureStatusScheduled, FixtureStatusOngoing, FixtureStatusCompleted, FixtureStatusCancelled:
+		return true
+	}
+	return false
+}
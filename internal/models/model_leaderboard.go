@@ -0,0 +1,78 @@
+package models
+
+import "time"
+
+// LeaderboardEntry is one row of a season leaderboard. Players with equal
+// accumulated points share the same position.
+type LeaderboardEntry struct {
+	Position          int     `json:"position"`
+	PlayerSeasonID    string  `json:"player_season_id"`
+	PlayerID          string  `json:"player_id"`
+	PlayerName        string  `json:"player_name"`
+	AvatarURL         *string `json:"avatar_url"`
+	TeamID            string  `json:"team_id"`
+	TeamName          string  `json:"team_name"`
+	RankID            string  `json:"rank_id"`
+	RankName          *string `json:"rank_name"`
+	AccumulatedPoints float64 `json:"accumulated_points"`
+}
+
+// LeaderboardFilter holds the query of GET /seasons/{seasonId}/leaderboard
+type LeaderboardFilter struct {
+	SeasonID string
+	TeamID   string
+	RankID   string
+	Fresh    bool // read the live view instead of the materialized one
+	Limit    int
+	Offset   int
+}
+
+// LeaderboardResponse for GET /seasons/{seasonId}/leaderboard
+type LeaderboardResponse struct {
+	SeasonID       string             `json:"season_id"`
+	Fresh          bool               `json:"fresh"`
+	MaterializedAt *time.Time         `json:"materialized_at"`
+	Page           int                `json:"page"`
+	PageSize       int                `json:"page_size"`
+	Total          int                `json:"total"`
+	Entries        []LeaderboardEntry `json:"entries"`
+}
+
+// Movement of a player between two round snapshots
+const (
+	MovementUp   = "UP"
+	MovementDown = "DOWN"
+	MovementSame = "SAME"
+	MovementNew  = "NEW"
+)
+
+// RoundLeaderboardEntry is one row of the leaderboard snapshot taken after a
+// round, compared with the previous snapshot of the season
+type RoundLeaderboardEntry struct {
+	LeaderboardEntry
+	PreviousPosition *int   `json:"previous_position"`
+	PositionChange   int    `json:"position_change"` // positive when the player climbed
+	Movement         string `json:"movement"`        // UP, DOWN, SAME, NEW
+}
+
+// RoundLeaderboardFilter holds the query of GET /seasons/{seasonId}/rounds/{round}/leaderboard
+type RoundLeaderboardFilter struct {
+	SeasonID    string
+	RoundNumber int
+	TeamID      string
+	RankID      string
+	Limit       int
+	Offset      int
+}
+
+// RoundLeaderboardResponse for GET /seasons/{seasonId}/rounds/{round}/leaderboard
+type RoundLeaderboardResponse struct {
+	SeasonID      string                  `json:"season_id"`
+	RoundNumber   int                     `json:"round_number"`
+	PreviousRound *int                    `json:"previous_round"`
+	FinalizedAt   *time.Time              `json:"finalized_at"`
+	Page          int                     `json:"page"`
+	PageSize      int                     `json:"page_size"`
+	Total         int                     `json:"total"`
+	Entries       []RoundLeaderboardEntry `json:"entries"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Lineup statuses
+const (
+	LineupStatusSubmitted = "SUBMITTED"
+	LineupStatusLocked    = "LOCKED"
+)
+
+// LineupEntry is the player, or doubles pair, a team puts in one rubber slot
+type LineupEntry struct {
+	MatchOrder int     `json:"match_order" binding:"required"`
+	MatchType  string  `json:"match_type"` // SINGLE, DOUBLE; set from the league format
+	Player1ID  string  `json:"player1_id" binding:"required"`
+	Player2ID  *string `json:"player2_id,omitempty"`
+}
+
+// PlayerIDs returns the player ids of the slot (one for singles, two for doubles)
+func (e *LineupEntry) PlayerIDs() []string {
+	if e.Player2ID == nil {
+		return []string{e.Player1ID}
+	}
+	return []string{e.Player1ID, *e.Player2ID}
+}
+
+// Lineup is the ordered lineup a team submits for a fixture
+type Lineup struct {
+	ID          string        `json:"id"`
+	FixtureID   string        `json:"fixture_id"`
+	TeamID      string        `json:"team_id"`
+	Status      string        `json:"status"` // SUBMITTED, LOCKED
+	Entries     []LineupEntry `json:"entries"`
+	SubmittedAt time.Time     `json:"submitted_at"`
+	LockedAt    *time.Time    `json:"locked_at,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// SubmitLineupRequest is the body of PUT /seasons/{seasonId}/fixtures/{fixtureId}/lineups/{teamId}
+type SubmitLineupRequest struct {
+	Entries []LineupEntry `json:"entries" binding:"required,dive"`
+}
+
+// LineupLock is the result of locking the lineups of a fixture. Matches holds
+// the rubbers pre-filled once the lineups of both teams are locked.
+type LineupLock struct {
+	Fixture Fixture  `json:"fixture"`
+	Lineups []Lineup `json:"lineups"`
+	Matches []Match  `json:"matches"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// Match types
+const (
+	MatchTypeSingle = "SINGLE"
+	MatchTypeDouble = "DOUBLE"
+)
+
+// Match result types. Every result gives the rubber to the winner in the
+// fixture score. A walkover has no sets and leaves ratings unchanged; a
+// retirement or disqualification keeps the sets played so far and is rated as
+// a win for the other side.
+const (
+	ResultTypeNormal       = "NORMAL"
+	ResultTypeWalkover     = "WALKOVER"
+	ResultTypeRetired      = "RETIRED"
+	ResultTypeDisqualified = "DISQUALIFIED"
+)
+
+// Match represents one rubber (individual sub-match) of a fixture
+type Match struct {
+	ID               string    `json:"id"`
+	FixtureID        string    `json:"fixture_id"`
+	MatchOrder       int       `json:"match_order"`
+	MatchType        string    `json:"match_type"` // SINGLE, DOUBLE
+	HomePlayer1ID    string    `json:"home_player1_id"`
+	HomePlayer2ID    *string   `json:"home_player2_id,omitempty"`
+	GuestPlayer1ID   string    `json:"guest_player1_id"`
+	GuestPlayer2ID   *string   `json:"guest_player2_id,omitempty"`
+	HandicapSnapshot *string   `json:"handicap_snapshot,omitempty"`
+	RankSnapshot     *string   `json:"rank_snapshot,omitempty"`
+	PointBefore      *string   `json:"point_before,omitempty"`
+	PointAfter       *string   `json:"point_after,omitempty"`
+	HomeSets         []int64   `json:"home_sets"`
+	GuestSets        []int64   `json:"guest_sets"`
+	WinnerTeamID     *string   `json:"winner_team_id,omitempty"`
+	ResultType       string    `json:"result_type"` // NORMAL, WALKOVER, RETIRED, DISQUALIFIED
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Played reports whether the result of the rubber has been recorded; rubbers
+// pre-filled from the lineups have no winner yet
+func (m *Match) Played() bool {
+	return m.WinnerTeamID != nil
+}
+
+// Result returns the recorded outcome of the rubber
+func (m *Match) Result() MatchResult {
+	return MatchResult{
+		HomeSets:     m.HomeSets,
+		GuestSets:    m.GuestSets,
+		WinnerTeamID: m.WinnerTeamID,
+		ResultType:   m.ResultType,
+	}
+}
+
+// HomePlayerIDs returns the home side player ids (one for singles, two for doubles)
+func (m *Match) HomePlayerIDs() []string {
+	if m.HomePlayer2ID == nil {
+		return []string{m.HomePlayer1ID}
+	}
+	return []string{m.HomePlayer1ID, *m.HomePlayer2ID}
+}
+
+// GuestPlayerIDs returns the guest side player ids (one for singles, two for doubles)
+func (m *Match) GuestPlayerIDs() []string {
+	if m.GuestPlayer2ID == nil {
+		return []string{m.GuestPlayer1ID}
+	}
+	return []string{m.GuestPlayer1ID, *m.GuestPlayer2ID}
+}
+
+// IsValidResultType reports whether resultType is a known match result type
+func IsValidResultType(resultType string) bool {
+	switch resultType {
+	case ResultTypeNormal, ResultTypeWalkover, ResultTypeRetired, ResultTypeDisqualified:
+		return true
+	}
+	return false
+}
+
+// Rated reports whether the result changes the players' ratings; walkovers are not rated
+func (m *Match) Rated() bool {
+	return m.ResultType != ResultTypeWalkover
+}
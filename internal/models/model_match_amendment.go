@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// MatchResult is the recorded outcome of a rubber
+type MatchResult struct {
+	HomeSets     []int64 `json:"home_sets"`
+	GuestSets    []int64 `json:"guest_sets"`
+	WinnerTeamID *string `json:"winner_team_id,omitempty"`
+	ResultType   string  `json:"result_type"`
+}
+
+// MatchAmendment is an audited correction of a recorded rubber
+type MatchAmendment struct {
+	ID        string      `json:"id"`
+	MatchID   string      `json:"match_id"`
+	Reason    string      `json:"reason"`
+	Before    MatchResult `json:"before"`
+	After     MatchResult `json:"after"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// AmendedRating is a player's rating change in one rubber before and after
+// an amendment was replayed
+type AmendedRating struct {
+	MatchID     string  `json:"match_id"`
+	RoundNumber int     `json:"round_number"`
+	PlayerID    string  `json:"player_id"`
+	DeltaBefore float64 `json:"delta_before"`
+	DeltaAfter  float64 `json:"delta_after"`
+}
+
+// AmendedPoints is a player's accumulated points before and after an amendment
+type AmendedPoints struct {
+	PlayerSeasonID string  `json:"player_season_id"`
+	PlayerID       string  `json:"player_id"`
+	PointsBefore   float64 `json:"points_before"`
+	PointsAfter    float64 `json:"points_after"`
+}
+
+// MatchAmendmentResult is the diff returned by
+// POST /seasons/{seasonId}/fixtures/{fixtureId}/matches/{matchId}/amend
+type MatchAmendmentResult struct {
+	Amendment         MatchAmendment  `json:"amendment"`
+	Match             *Match          `json:"match"`
+	FixtureBefore     Fixture         `json:"fixture_before"`
+	FixtureAfter      *Fixture        `json:"fixture_after"`
+	Ratings           []AmendedRating `json:"ratings"`
+	Points            []AmendedPoints `json:"points"`
+	RegeneratedRounds []int           `json:"regenerated_rounds"`
+	RankChanges       []RankChange    `json:"rank_changes"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "backend-ping-pong-app/internal/handicap"
+
+// MatchSheetPlayer is a player line of a match sheet with the rank they played at
+type MatchSheetPlayer struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	RankID     string `json:"rank_id"`
+}
+
+// MatchSheetRubber is one rubber of a match sheet. Handicap is nil when the
+// season plays without handicap. A rubber taken from the lineups that has no
+// row yet has no match id, sets or result type.
+type MatchSheetRubber struct {
+	MatchID      string             `json:"match_id"`
+	MatchOrder   int                `json:"match_order"`
+	MatchType    string             `json:"match_type"`
+	HomePlayers  []MatchSheetPlayer `json:"home_players"`
+	GuestPlayers []MatchSheetPlayer `json:"guest_players"`
+	Handicap     *handicap.Result   `json:"handicap"`
+	HomeSets     []int64            `json:"home_sets"`
+	GuestSets    []int64            `json:"guest_sets"`
+	WinnerTeamID *string            `json:"winner_team_id,omitempty"`
+	ResultType   string             `json:"result_type"`
+}
+
+// MatchSheet for GET /seasons/{seasonId}/fixtures/{fixtureId}/match-sheet,
+// telling umpires the starting score of every rubber
+type MatchSheet struct {
+	Fixture       Fixture            `json:"fixture"`
+	HomeTeamName  string             `json:"home_team_name"`
+	GuestTeamName string             `json:"guest_team_name"`
+	Rubbers       []MatchSheetRubber `json:"rubbers"`
+}
@@ -3,14 +3,24 @@ package models
 import "time"
 
 type Player struct {
-	ID        string    `json:"id"`
-	FullName  string    `json:"full_name"`
-	BirthYear *int      `json:"birth_year,omitempty"`
-	Phone     *string   `json:"phone,omitempty"`
-	CCCD      *string   `json:"cccd,omitempty"`
-	AvatarURL *string   `json:"avatar_url,omitempty"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
+	ID          string    `json:"id"`
+	FullName    string    `json:"full_name"`
+	BirthYear   *int      `json:"birth_year,omitempty"`
+	Phone       *string   `json:"phone,omitempty"`
+	CCCD        *string   `json:"cccd,omitempty"`
+	AvatarURL   *string   `json:"avatar_url,omitempty"`
+	FirebaseUID *string   `json:"firebase_uid,omitempty"` // mobile app account
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PlayerProfileUpdate is the editable part of a player's profile; nil fields
+// are left unchanged
+type PlayerProfileUpdate struct {
+	FullName  *string `json:"full_name"`
+	BirthYear *int    `json:"birth_year"`
+	Phone     *string `json:"phone"`
+	AvatarURL *string `json:"avatar_url"`
 }
 
 type PlayerListResponse struct {
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Player season statuses
+const (
+	PlayerSeasonStatusActive    = "ACTIVE"
+	PlayerSeasonStatusInactive  = "INACTIVE"
+	PlayerSeasonStatusWithdrawn = "WITHDRAWN"
+)
+
+// PlayerSeason is a player's registration in a season (team, rank and points)
+type PlayerSeason struct {
+	ID                string    `json:"id"`
+	SeasonID          string    `json:"season_id"`
+	PlayerID          string    `json:"player_id"`
+	TeamID            string    `json:"team_id"`
+	RankID            string    `json:"rank_id"`
+	AccumulatedPoints float64   `json:"accumulated_points"`
+	Status            string    `json:"status"` // ACTIVE, INACTIVE, WITHDRAWN
+	DisplayOrder      *int      `json:"display_order,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// RosterEntry for GET /seasons/{seasonId}/players
+type RosterEntry struct {
+	PlayerSeason
+	PlayerName string  `json:"player_name"`
+	AvatarURL  *string `json:"avatar_url"`
+	TeamName   string  `json:"team_name"`
+}
+
+// IsValidPlayerSeasonStatus reports whether status is a known player season status
+func IsValidPlayerSeasonStatus(status string) bool {
+	switch status {
+	case PlayerSeasonStatusActive, PlayerSeasonStatusInactive, PlayerSeasonStatusWithdrawn:
+		return true
+	}
+	return false
+}
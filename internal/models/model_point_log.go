@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Point log sources
+const (
+	PointSourceMatch       = "MATCH"
+	PointSourceTournament  = "TOURNAMENT"
+	PointSourceAdminAdjust = "ADMIN_ADJUST"
+	PointSourcePenalty     = "PENALTY"
+	PointSourceBonus       = "BONUS"
+)
+
+// PointLog is one audited change of a player's season points
+type PointLog struct {
+	ID             string    `json:"id"`
+	PlayerSeasonID string    `json:"player_season_id"`
+	DeltaPoints    float64   `json:"delta_points"`
+	Reason         *string   `json:"reason,omitempty"`
+	Source         string    `json:"source"` // MATCH, TOURNAMENT, ADMIN_ADJUST, PENALTY, BONUS
+	RefID          *string   `json:"ref_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// IsAdjustmentSource reports whether source can be used for a manual point adjustment
+func IsAdjustmentSource(source string) bool {
+	switch source {
+	case PointSourceAdminAdjust, PointSourcePenalty, PointSourceBonus:
+		return true
+	}
+	return false
+}
+
+// PointAdjustment is the result of POST /player-seasons/{id}/adjustments
+type PointAdjustment struct {
+	Log          PointLog `json:"log"`
+	PointsBefore float64  `json:"points_before"`
+	PointsAfter  float64  `json:"points_after"`
+}
+
+// PointHistoryEntry is a point log with the player's points once every
+// applied log up to it is counted. MATCH deltas of a round that is not
+// finalized yet are pending and leave the running total unchanged.
+type PointHistoryEntry struct {
+	PointLog
+	Applied      bool    `json:"applied"`
+	RunningTotal float64 `json:"running_total"`
+}
+
+// PointHistory for GET /player-seasons/{id}/point-history. StartingPoints is
+// what the player had before the first log, e.g. when registered.
+type PointHistory struct {
+	PlayerSeasonID    string              `json:"player_season_id"`
+	StartingPoints    float64             `json:"starting_points"`
+	AccumulatedPoints float64             `json:"accumulated_points"`
+	PendingPoints     float64             `json:"pending_points"`
+	Entries           []PointHistoryEntry `json:"entries"`
+}
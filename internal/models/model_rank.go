@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Rank is a skill level (C3 ... A1) with its score band
+type Rank struct {
+	ID            string  `json:"id"`
+	SortOrder     int     `json:"sort_order"`
+	MinScore      *int    `json:"min_score,omitempty"`
+	MaxScore      *int    `json:"max_score,omitempty"`
+	StandardScore int     `json:"standard_score"`
+	KFactor       int     `json:"k_factor"`
+	Description   *string `json:"description,omitempty"`
+}
+
+// RankChange is a promotion or relegation recorded in player_rank_history
+type RankChange struct {
+	ID             string    `json:"id"`
+	PlayerSeasonID string    `json:"player_season_id"`
+	PlayerID       string    `json:"player_id"`
+	SeasonID       string    `json:"season_id"`
+	RoundNumber    int       `json:"round_number"`
+	OldRankID      string    `json:"old_rank_id"`
+	NewRankID      string    `json:"new_rank_id"`
+	Points         float64   `json:"points"`
+	CreatedAt      time.Time `json:"created_at"`
+}
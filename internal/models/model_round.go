@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Round statuses
+const (
+	RoundStatusOpen      = "OPEN"
+	RoundStatusLocked    = "LOCKED"
+	RoundStatusFinalized = "FINALIZED"
+)
+
+// Round tracks the lifecycle of one round of a season
+type Round struct {
+	ID          string     `json:"id"`
+	SeasonID    string     `json:"season_id"`
+	RoundNumber int        `json:"round_number"`
+	Status      string     `json:"status"` // OPEN, LOCKED, FINALIZED
+	FinalizedAt *time.Time `json:"finalized_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// PlayerRoundPoints is the points a player earned in a round
+type PlayerRoundPoints struct {
+	PlayerSeasonID string  `json:"player_season_id"`
+	PlayerID       string  `json:"player_id"`
+	SeasonID       string  `json:"season_id"`
+	RoundNumber    int     `json:"round_number"`
+	PointsBefore   float64 `json:"points_before"`
+	PointsEarned   float64 `json:"points_earned"`
+	PointsAfter    float64 `json:"points_after"`
+}
+
+// PlayerRoundStanding is a player's leaderboard position after a round
+type PlayerRoundStanding struct {
+	PlayerSeasonID    string  `json:"player_season_id"`
+	SeasonID          string  `json:"season_id"`
+	RoundNumber       int     `json:"round_number"`
+	TeamID            *string `json:"team_id"`
+	RankID            *string `json:"rank_id"`
+	AccumulatedPoints float64 `json:"accumulated_points"`
+	RankPosition      int     `json:"rank_position"`
+}
+
+// RoundFinalization is the result of POST /seasons/{id}/rounds/{round}/finalize.
+// With DryRun set nothing has been committed.
+type RoundFinalization struct {
+	SeasonID    string                `json:"season_id"`
+	RoundNumber int                   `json:"round_number"`
+	DryRun      bool                  `json:"dry_run"`
+	Round       *Round                `json:"round"`
+	Points      []PlayerRoundPoints   `json:"points"`
+	RankChanges []RankChange          `json:"rank_changes"`
+	Standings   []PlayerRoundStanding `json:"standings"`
+	Teams       []TeamStanding        `json:"team_standings"`
+}
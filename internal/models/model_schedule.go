@@ -0,0 +1,25 @@
+package models
+
+// ScheduleRequest is the body of POST /seasons/{seasonId}/schedule/generate
+type ScheduleRequest struct {
+	Double     bool `json:"double"`      // play every pairing home and away
+	StartRound int  `json:"start_round"` // round of the first generated fixtures, defaults to 1
+}
+
+// ScheduleBye records a team without a fixture in a round when the season has
+// an odd number of teams
+type ScheduleBye struct {
+	Round  int    `json:"round"`
+	TeamID string `json:"team_id"`
+}
+
+// GeneratedSchedule is the result of a round-robin generation. With Preview
+// set nothing has been stored and fixtures have no id.
+type GeneratedSchedule struct {
+	SeasonID string        `json:"season_id"`
+	Preview  bool          `json:"preview"`
+	Double   bool          `json:"double"`
+	Rounds   int           `json:"rounds"`
+	Fixtures []Fixture     `json:"fixtures"`
+	Byes     []ScheduleBye `json:"byes"`
+}
@@ -2,6 +2,13 @@ package models
 
 import "time"
 
+// Season statuses
+const (
+	SeasonStatusUpcoming = "UPCOMING"
+	SeasonStatusActive   = "ACTIVE"
+	SeasonStatusFinished = "FINISHED"
+)
+
 // Season represents a league season
 type Season struct {
 	ID        string    `json:"id"`
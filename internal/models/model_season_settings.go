@@ -0,0 +1,158 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"backend-ping-pong-app/internal/handicap"
+)
+
+// Default season rules used when a season has no settings row
+const (
+	DefaultPromotionBuffer       = 25
+	DefaultRelegationBuffer      = 25
+	DefaultMaxTransfersPerPlayer = 1
+	DefaultPointsPerWin          = 3
+	DefaultPointsPerDraw         = 1
+	DefaultPointsPerLoss         = 0
+
+	// Nine singles rubbers between three players each
+	DefaultMaxAppearancesPerFixture = 3
+
+	DefaultNoShowPenaltyPoints = 20
+)
+
+// SeasonSettings holds the configurable rules of a season
+type SeasonSettings struct {
+	SeasonID         string `json:"season_id"`
+	PromotionBuffer  int    `json:"promotion_buffer"`
+	RelegationBuffer int    `json:"relegation_buffer"`
+
+	// Transfers are allowed when the last round with the old team lies in
+	// [start, end]; a nil bound leaves that side of the window open
+	TransferWindowStartRound *int `json:"transfer_window_start_round"`
+	TransferWindowEndRound   *int `json:"transfer_window_end_round"`
+	MaxTransfersPerPlayer    int  `json:"max_transfers_per_player"`
+
+	// Team standings points awarded for each fixture result
+	PointsPerWin  int `json:"points_per_win"`
+	PointsPerDraw int `json:"points_per_draw"`
+	PointsPerLoss int `json:"points_per_loss"`
+
+	// The weaker side of a rubber starts every set with one point per
+	// HandicapStep of standard score difference, up to HandicapMaxPoints
+	HandicapEnabled   bool `json:"handicap_enabled"`
+	HandicapStep      int  `json:"handicap_step"`
+	HandicapMaxPoints int  `json:"handicap_max_points"`
+
+	// Rubbers one player may appear in (singles and doubles) per fixture lineup
+	MaxAppearancesPerFixture int `json:"max_appearances_per_fixture"`
+
+	// Season points deducted from a player who gives an unjustified walkover
+	NoShowPenaltyPoints int `json:"no_show_penalty_points"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// HandicapFormula returns the season's handicap formula, or nil when
+// handicaps are disabled
+func (s *SeasonSettings) HandicapFormula() *handicap.Formula {
+	if !s.HandicapEnabled {
+		return nil
+	}
+	return &handicap.Formula{Step: s.HandicapStep, MaxPoints: s.HandicapMaxPoints}
+}
+
+// NullableInt is a field of a partial update that can be cleared: an omitted
+// field is not Set, an explicit null is Set with a nil Value
+type NullableInt struct {
+	Set   bool
+	Value *int
+}
+
+func (n *NullableInt) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	return json.Unmarshal(data, &n.Value)
+}
+
+// SeasonSettingsUpdate is the body of PUT /seasons/{seasonId}/settings;
+// nil fields keep their current value. The transfer window bounds are
+// cleared, opening that side of the window, by sending them as null.
+type SeasonSettingsUpdate struct {
+	PromotionBuffer          *int        `json:"promotion_buffer"`
+	RelegationBuffer         *int        `json:"relegation_buffer"`
+	TransferWindowStartRound NullableInt `json:"transfer_window_start_round"`
+	TransferWindowEndRound   NullableInt `json:"transfer_window_end_round"`
+	MaxTransfersPerPlayer    *int        `json:"max_transfers_per_player"`
+	PointsPerWin             *int        `json:"points_per_win"`
+	PointsPerDraw            *int        `json:"points_per_draw"`
+	PointsPerLoss            *int        `json:"points_per_loss"`
+	HandicapEnabled          *bool       `json:"handicap_enabled"`
+	HandicapStep             *int        `json:"handicap_step"`
+	HandicapMaxPoints        *int        `json:"handicap_max_points"`
+	MaxAppearancesPerFixture *int        `json:"max_appearances_per_fixture"`
+	NoShowPenaltyPoints      *int        `json:"no_show_penalty_points"`
+}
+
+// Apply copies the non-nil fields and the set transfer window bounds of the
+// update onto settings
+func (u SeasonSettingsUpdate) Apply(settings *SeasonSettings) {
+	if u.PromotionBuffer != nil {
+		settings.PromotionBuffer = *u.PromotionBuffer
+	}
+	if u.RelegationBuffer != nil {
+		settings.RelegationBuffer = *u.RelegationBuffer
+	}
+	if u.TransferWindowStartRound.Set {
+		settings.TransferWindowStartRound = u.TransferWindowStartRound.Value
+	}
+	if u.TransferWindowEndRound.Set {
+		settings.TransferWindowEndRound = u.TransferWindowEndRound.Value
+	}
+	if u.MaxTransfersPerPlayer != nil {
+		settings.MaxTransfersPerPlayer = *u.MaxTransfersPerPlayer
+	}
+	if u.PointsPerWin != nil {
+		settings.PointsPerWin = *u.PointsPerWin
+	}
+	if u.PointsPerDraw != nil {
+		settings.PointsPerDraw = *u.PointsPerDraw
+	}
+	if u.PointsPerLoss != nil {
+		settings.PointsPerLoss = *u.PointsPerLoss
+	}
+	if u.HandicapEnabled != nil {
+		settings.HandicapEnabled = *u.HandicapEnabled
+	}
+	if u.HandicapStep != nil {
+		settings.HandicapStep = *u.HandicapStep
+	}
+	if u.HandicapMaxPoints != nil {
+		settings.HandicapMaxPoints = *u.HandicapMaxPoints
+	}
+	if u.MaxAppearancesPerFixture != nil {
+		settings.MaxAppearancesPerFixture = *u.MaxAppearancesPerFixture
+	}
+	if u.NoShowPenaltyPoints != nil {
+		settings.NoShowPenaltyPoints = *u.NoShowPenaltyPoints
+	}
+}
+
+// DefaultSeasonSettings returns the default rules for a season
+func DefaultSeasonSettings(seasonID string) *SeasonSettings {
+	return &SeasonSettings{
+		SeasonID:              seasonID,
+		PromotionBuffer:       DefaultPromotionBuffer,
+		RelegationBuffer:      DefaultRelegationBuffer,
+		MaxTransfersPerPlayer: DefaultMaxTransfersPerPlayer,
+		PointsPerWin:          DefaultPointsPerWin,
+		PointsPerDraw:         DefaultPointsPerDraw,
+		PointsPerLoss:         DefaultPointsPerLoss,
+		HandicapEnabled:       true,
+		HandicapStep:          handicap.DefaultStep,
+		HandicapMaxPoints:     handicap.DefaultMaxPoints,
+
+		MaxAppearancesPerFixture: DefaultMaxAppearancesPerFixture,
+		NoShowPenaltyPoints:      DefaultNoShowPenaltyPoints,
+	}
+}
@@ -9,6 +9,12 @@ type Team struct {
 	AvatarURL *string `json:"avatar_url"`
 }
 
+// Transfer types
+const (
+	TransferTypeInitial  = "INITIAL"
+	TransferTypeTransfer = "TRANSFER"
+)
+
 // TeamMember represents a player membership in a team
 type TeamMember struct {
 	TeamID       string `json:"team_id"`
@@ -17,3 +23,20 @@ type TeamMember struct {
 	LeftRound    *int   `json:"left_round,omitempty"`
 	TransferType string `json:"transfer_type"` // INITIAL, TRANSFER
 }
+
+// TransferRequest is the body of POST /teams/{teamId}/transfer
+type TransferRequest struct {
+	PlayerID  string `json:"player_id" binding:"required"`
+	ToTeamID  string `json:"to_team_id" binding:"required"`
+	LastRound int    `json:"last_round" binding:"required"` // last round played for the old team
+}
+
+// Transfer is the result of a mid-season transfer
+type Transfer struct {
+	PlayerSeasonID string     `json:"player_season_id"`
+	PlayerID       string     `json:"player_id"`
+	FromTeamID     string     `json:"from_team_id"`
+	ToTeamID       string     `json:"to_team_id"`
+	Closed         TeamMember `json:"closed_membership"`
+	Opened         TeamMember `json:"opened_membership"`
+}
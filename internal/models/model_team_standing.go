@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// TeamStanding is a team's row in the season standings, computed from its
+// completed fixtures
+type TeamStanding struct {
+	SeasonID    string    `json:"season_id"`
+	TeamID      string    `json:"team_id"`
+	TeamName    string    `json:"team_name"`
+	Position    int       `json:"position"`
+	Played      int       `json:"played"`
+	Wins        int       `json:"wins"`
+	Draws       int       `json:"draws"`
+	Losses      int       `json:"losses"`
+	RubbersWon  int       `json:"rubbers_won"`
+	RubbersLost int       `json:"rubbers_lost"`
+	SetsWon     int       `json:"sets_won"`
+	SetsLost    int       `json:"sets_lost"`
+	PointsWon   int       `json:"points_won"`
+	PointsLost  int       `json:"points_lost"`
+	TotalPoints int       `json:"total_points"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// FixtureResult is the aggregated score of a completed fixture: rubbers, sets
+// and table tennis points won by each side
+type FixtureResult struct {
+	FixtureID    string
+	Round        int
+	HomeTeamID   string
+	GuestTeamID  string
+	HomeRubbers  int
+	GuestRubbers int
+	HomeSets     int
+	GuestSets    int
+	HomePoints   int
+	GuestPoints  int
+}
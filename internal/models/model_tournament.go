@@ -0,0 +1,112 @@
+package models
+
+import "time"
+
+// Tournament statuses
+const (
+	TournamentStatusRegistration = "REGISTRATION"
+	TournamentStatusGroupStage   = "GROUP_STAGE"
+	TournamentStatusKnockout     = "KNOCKOUT"
+	TournamentStatusCompleted    = "COMPLETED"
+)
+
+// Tournament match stages
+const (
+	TournamentStageGroup    = "GROUP"
+	TournamentStageKnockout = "KNOCKOUT"
+)
+
+// Tournament is an individual singles cup played inside a season. Players
+// enter directly, play round-robin groups, and the best of each group move
+// on to a single elimination knockout.
+type Tournament struct {
+	ID                 string    `json:"id"`
+	SeasonID           string    `json:"season_id"`
+	Name               string    `json:"name"`
+	GroupCount         int       `json:"group_count"`
+	QualifiersPerGroup int       `json:"qualifiers_per_group"`
+	Status             string    `json:"status"` // REGISTRATION, GROUP_STAGE, KNOCKOUT, COMPLETED
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// TournamentEntry is a player entered in a tournament. Team is the player's
+// season team, used to keep teammates apart in the group draw.
+type TournamentEntry struct {
+	ID             string    `json:"id"`
+	TournamentID   string    `json:"tournament_id"`
+	PlayerID       string    `json:"player_id"`
+	PlayerName     string    `json:"player_name"`
+	PlayerSeasonID string    `json:"player_season_id"`
+	TeamID         *string   `json:"team_id"`
+	Seed           *int      `json:"seed"`
+	GroupNumber    *int      `json:"group_number"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TournamentMatch is a singles match of a tournament. Knockout matches are
+// created empty and filled as winners advance.
+type TournamentMatch struct {
+	ID            string    `json:"id"`
+	TournamentID  string    `json:"tournament_id"`
+	Stage         string    `json:"stage"` // GROUP, KNOCKOUT
+	GroupNumber   *int      `json:"group_number"`
+	Round         int       `json:"round"`
+	Position      int       `json:"position"`
+	HomeEntryID   *string   `json:"home_entry_id"`
+	GuestEntryID  *string   `json:"guest_entry_id"`
+	HomeSets      []int64   `json:"home_sets"`
+	GuestSets     []int64   `json:"guest_sets"`
+	WinnerEntryID *string   `json:"winner_entry_id"`
+	PointBefore   *string   `json:"point_before"`
+	PointAfter    *string   `json:"point_after"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// TournamentGroupRow is one line of a group table
+type TournamentGroupRow struct {
+	EntryID    string `json:"entry_id"`
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Played     int    `json:"played"`
+	Wins       int    `json:"wins"`
+	Losses     int    `json:"losses"`
+	SetsWon    int    `json:"sets_won"`
+	SetsLost   int    `json:"sets_lost"`
+	PointsWon  int    `json:"points_won"`
+	PointsLost int    `json:"points_lost"`
+}
+
+// TournamentGroup is a group with its table and matches
+type TournamentGroup struct {
+	GroupNumber int                  `json:"group_number"`
+	Table       []TournamentGroupRow `json:"table"`
+	Matches     []TournamentMatch    `json:"matches"`
+}
+
+// TournamentDetail for GET /tournaments/{tournamentId}
+type TournamentDetail struct {
+	Tournament
+	Entries  []TournamentEntry `json:"entries"`
+	Groups   []TournamentGroup `json:"groups"`
+	Knockout []TournamentMatch `json:"knockout"`
+}
+
+// CreateTournamentRequest is the body of POST /seasons/{seasonId}/tournaments
+type CreateTournamentRequest struct {
+	Name               string `json:"name" binding:"required"`
+	GroupCount         int    `json:"group_count" binding:"required"`
+	QualifiersPerGroup int    `json:"qualifiers_per_group" binding:"required"`
+}
+
+// TournamentEntriesRequest is the body of POST /tournaments/{tournamentId}/entries
+type TournamentEntriesRequest struct {
+	PlayerIDs []string `json:"player_ids" binding:"required"`
+}
+
+// TournamentResultRequest is the body of POST /tournaments/{tournamentId}/matches/{matchId}/result
+type TournamentResultRequest struct {
+	HomeSets  []int64 `json:"home_sets" binding:"required"`
+	GuestSets []int64 `json:"guest_sets" binding:"required"`
+}
@@ -0,0 +1,75 @@
+package rating
+
+import "math"
+
+const (
+	// DefaultKFactor is used for participants without a rank specific K-factor
+	DefaultKFactor = 32
+
+	// DefaultScale is the rating difference at which the stronger side is
+	// expected to win 10 times out of 11
+	DefaultScale = 400
+)
+
+// Elo is the default Calculator. Doubles pairs play with the average rating
+// of both partners and each partner's change uses their own K-factor.
+type Elo struct {
+	Scale float64
+}
+
+func NewElo() *Elo {
+	return &Elo{Scale: DefaultScale}
+}
+
+func (e *Elo) Deltas(outcome Outcome) map[string]float64 {
+	homeRating := averageRating(outcome.Home)
+	guestRating := averageRating(outcome.Guest)
+
+	homeExpected := e.expectedScore(homeRating, guestRating)
+	homeScore := 0.0
+	if outcome.HomeWon {
+		homeScore = 1
+	}
+
+	deltas := make(map[string]float64, len(outcome.Home)+len(outcome.Guest))
+	for _, p := range outcome.Home {
+		deltas[p.PlayerID] = roundPoints(kFactor(p) * (homeScore - homeExpected))
+	}
+	for _, p := range outcome.Guest {
+		deltas[p.PlayerID] = roundPoints(kFactor(p) * ((1 - homeScore) - (1 - homeExpected)))
+	}
+	return deltas
+}
+
+// expectedScore is the probability that a side rated a beats a side rated b
+func (e *Elo) expectedScore(a, b float64) float64 {
+	scale := e.Scale
+	if scale <= 0 {
+		scale = DefaultScale
+	}
+	return 1 / (1 + math.Pow(10, (b-a)/scale))
+}
+
+func averageRating(side []Participant) float64 {
+	if len(side) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, p := range side {
+		total += p.Rating
+	}
+	return total / float64(len(side))
+}
+
+func kFactor(p Participant) float64 {
+	if p.KFactor <= 0 {
+		return DefaultKFactor
+	}
+	return p.KFactor
+}
+
+// roundPoints rounds to the 2 decimals stored in player_point_logs.delta_points
+func roundPoints(points float64) float64 {
+	return math.Round(points*100) / 100
+}
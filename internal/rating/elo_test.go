@@ -0,0 +1,101 @@
+package rating
+
+import "testing"
+
+func TestEloDeltas(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome Outcome
+		want    map[string]float64
+	}{
+		{
+			name: "equal ratings at K=32",
+			outcome: Outcome{
+				Home:    []Participant{{PlayerID: "a", Rating: 1000, KFactor: 32}},
+				Guest:   []Participant{{PlayerID: "b", Rating: 1000, KFactor: 32}},
+				HomeWon: true,
+			},
+			want: map[string]float64{"a": 16, "b": -16},
+		},
+		{
+			name: "400 point favourite wins",
+			outcome: Outcome{
+				Home:    []Participant{{PlayerID: "a", Rating: 1400, KFactor: 32}},
+				Guest:   []Participant{{PlayerID: "b", Rating: 1000, KFactor: 32}},
+				HomeWon: true,
+			},
+			want: map[string]float64{"a": 2.91, "b": -2.91},
+		},
+		{
+			name: "400 point favourite loses",
+			outcome: Outcome{
+				Home:    []Participant{{PlayerID: "a", Rating: 1400, KFactor: 32}},
+				Guest:   []Participant{{PlayerID: "b", Rating: 1000, KFactor: 32}},
+				HomeWon: false,
+			},
+			want: map[string]float64{"a": -29.09, "b": 29.09},
+		},
+		{
+			name: "doubles average partners and keep their own K-factor",
+			outcome: Outcome{
+				Home: []Participant{
+					{PlayerID: "a", Rating: 1000, KFactor: 40},
+					{PlayerID: "b", Rating: 1200, KFactor: 24},
+				},
+				Guest: []Participant{
+					{PlayerID: "c", Rating: 1100, KFactor: 32},
+					{PlayerID: "d", Rating: 1100, KFactor: 16},
+				},
+				HomeWon: true,
+			},
+			want: map[string]float64{"a": 20, "b": 12, "c": -16, "d": -8},
+		},
+		{
+			name: "missing K-factor falls back to the default",
+			outcome: Outcome{
+				Home:    []Participant{{PlayerID: "a", Rating: 1000}},
+				Guest:   []Participant{{PlayerID: "b", Rating: 1000, KFactor: -1}},
+				HomeWon: false,
+			},
+			want: map[string]float64{"a": -DefaultKFactor / 2, "b": DefaultKFactor / 2},
+		},
+		{
+			name: "rounds to 2 decimals",
+			outcome: Outcome{
+				Home:    []Participant{{PlayerID: "a", Rating: 1100, KFactor: 32}},
+				Guest:   []Participant{{PlayerID: "b", Rating: 1000, KFactor: 32}},
+				HomeWon: true,
+			},
+			// 32 * (1 - 1/(1+10^-0.25)) = 11.5179...
+			want: map[string]float64{"a": 11.52, "b": -11.52},
+		},
+	}
+
+	elo := NewElo()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := elo.Deltas(tt.outcome)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Deltas() = %v, want %v", got, tt.want)
+			}
+			for playerID, want := range tt.want {
+				if got[playerID] != want {
+					t.Errorf("delta of %s = %v, want %v", playerID, got[playerID], want)
+				}
+			}
+		})
+	}
+}
+
+func TestEloZeroScaleUsesDefault(t *testing.T) {
+	outcome := Outcome{
+		Home:    []Participant{{PlayerID: "a", Rating: 1400, KFactor: 32}},
+		Guest:   []Participant{{PlayerID: "b", Rating: 1000, KFactor: 32}},
+		HomeWon: true,
+	}
+
+	got := (&Elo{}).Deltas(outcome)
+	if got["a"] != 2.91 {
+		t.Errorf("delta with zero scale = %v, want 2.91", got["a"])
+	}
+}
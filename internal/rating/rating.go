@@ -0,0 +1,21 @@
+package rating
+
+// Participant is a player's rating state going into a rubber
+type Participant struct {
+	PlayerID string
+	Rating   float64
+	KFactor  float64
+}
+
+// Outcome describes a finished rubber. Singles have one participant per side,
+// doubles have two.
+type Outcome struct {
+	Home    []Participant
+	Guest   []Participant
+	HomeWon bool
+}
+
+// Calculator computes the rating change of every player of a rubber, keyed by player id
+type Calculator interface {
+	Deltas(outcome Outcome) map[string]float64
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type AdminRepository interface {
+	GetAdminByIDRepo(ctx context.Context, id string) (*models.Admin, error)
+	GetAdminByEmailRepo(ctx context.Context, email string) (*models.Admin, error)
+	LockAdminRepo(ctx context.Context, id string) (*models.Admin, error)
+	GetAdminsRepo(ctx context.Context) ([]models.Admin, error)
+	CountActiveAdminsRepo(ctx context.Context, role string) (int, error)
+	CreateAdminRepo(ctx context.Context, admin *models.Admin) error
+	UpdateAdminPasswordRepo(ctx context.Context, id, passwordHash string, mustChange bool) error
+	UpdateAdminRoleRepo(ctx context.Context, id, role string) error
+	SetAdminActiveRepo(ctx context.Context, id string, active bool) error
+	GetTeamCaptainsRepo(ctx context.Context, teamID string) ([]models.Admin, error)
+	IsTeamCaptainRepo(ctx context.Context, teamID, adminID string) (bool, error)
+	AddTeamCaptainRepo(ctx context.Context, teamID, adminID string) error
+	RemoveTeamCaptainRepo(ctx context.Context, teamID, adminID string) error
+}
+
+type adminRepository struct {
+	db DBTX
+}
+
+func NewAdminRepository(db DBTX) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+const adminColumns = `id, email, password_hash, role, is_active, must_change_password, password_changed_at, created_at, updated_at`
+
+func scanAdmin(row rowScanner) (*models.Admin, error) {
+	var admin models.Admin
+	err := row.Scan(
+		&admin.ID,
+		&admin.Email,
+		&admin.PasswordHash,
+		&admin.Role,
+		&admin.IsActive,
+		&admin.MustChangePassword,
+		&admin.PasswordChangedAt,
+		&admin.CreatedAt,
+		&admin.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) GetAdminByIDRepo(ctx context.Context, id string) (*models.Admin, error) {
+	return r.getAdmin(ctx, id, "")
+}
+
+// LockAdminRepo reads an admin FOR UPDATE, serialising account changes
+func (r *adminRepository) LockAdminRepo(ctx context.Context, id string) (*models.Admin, error) {
+	return r.getAdmin(ctx, id, "FOR UPDATE")
+}
+
+func (r *adminRepository) getAdmin(ctx context.Context, id string, lockClause string) (*models.Admin, error) {
+	return scanAdmin(r.db.QueryRowContext(ctx, `
+		SELECT `+adminColumns+`
+		FROM admins
+		WHERE id = $1
+		`+lockClause, id))
+}
+
+// GetAdminByEmailRepo looks an admin up by email, ignoring case
+func (r *adminRepository) GetAdminByEmailRepo(ctx context.Context, email string) (*models.Admin, error) {
+	return scanAdmin(r.db.QueryRowContext(ctx, `
+		SELECT `+adminColumns+`
+		FROM admins
+		WHERE lower(email) = lower($1)
+	`, email))
+}
+
+func (r *adminRepository) GetAdminsRepo(ctx context.Context) ([]models.Admin, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+adminColumns+`
+		FROM admins
+		ORDER BY is_active DESC, email
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []models.Admin
+	for rows.Next() {
+		admin, err := scanAdmin(rows)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, *admin)
+	}
+	return admins, rows.Err()
+}
+
+// CountActiveAdminsRepo counts the active admins of a role, locking them so
+// concurrent demotions cannot both see the other as remaining
+func (r *adminRepository) CountActiveAdminsRepo(ctx context.Context, role string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM (SELECT id FROM admins WHERE role = $1 AND is_active FOR UPDATE) a
+	`, role).Scan(&count)
+	return count, err
+}
+
+// CreateAdminRepo inserts an admin, filling in its ID and timestamps
+func (r *adminRepository) CreateAdminRepo(ctx context.Context, admin *models.Admin) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO admins (email, password_hash, role, is_active, must_change_password)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, password_changed_at, created_at, updated_at
+	`,
+		admin.Email,
+		admin.PasswordHash,
+		admin.Role,
+		admin.IsActive,
+		admin.MustChangePassword,
+	).Scan(
+		&admin.ID,
+		&admin.PasswordChangedAt,
+		&admin.CreatedAt,
+		&admin.UpdatedAt,
+	)
+}
+
+// UpdateAdminPasswordRepo replaces a password, which invalidates the tokens
+// issued before it
+func (r *adminRepository) UpdateAdminPasswordRepo(ctx context.Context, id, passwordHash string, mustChange bool) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE admins
+		SET password_hash = $2, must_change_password = $3, password_changed_at = now(), updated_at = now()
+		WHERE id = $1
+	`, id, passwordHash, mustChange)
+	return err
+}
+
+func (r *adminRepository) UpdateAdminRoleRepo(ctx context.Context, id, role string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE admins SET role = $2, updated_at = now() WHERE id = $1`, id, role)
+	return err
+}
+
+func (r *adminRepository) SetAdminActiveRepo(ctx context.Context, id string, active bool) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE admins SET is_active = $2, updated_at = now() WHERE id = $1`, id, active)
+	return err
+}
+
+// GetTeamCaptainsRepo lists the admins captaining a team
+func (r *adminRepository) GetTeamCaptainsRepo(ctx context.Context, teamID string) ([]models.Admin, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.id, a.email, a.password_hash, a.role, a.is_active, a.must_change_password,
+			a.password_changed_at, a.created_at, a.updated_at
+		FROM team_captains tc
+		JOIN admins a ON a.id = tc.admin_id
+		WHERE tc.team_id = $1
+		ORDER BY tc.created_at, a.email
+	`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var captains []models.Admin
+	for rows.Next() {
+		admin, err := scanAdmin(rows)
+		if err != nil {
+			return nil, err
+		}
+		captains = append(captains, *admin)
+	}
+	return captains, rows.Err()
+}
+
+func (r *adminRepository) IsTeamCaptainRepo(ctx context.Context, teamID, adminID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM team_captains WHERE team_id = $1 AND admin_id = $2)
+	`, teamID, adminID).Scan(&exists)
+	return exists, err
+}
+
+// AddTeamCaptainRepo makes an admin captain of a team, doing nothing when
+// they already are
+func (r *adminRepository) AddTeamCaptainRepo(ctx context.Context, teamID, adminID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO team_captains (team_id, admin_id)
+		VALUES ($1, $2)
+		ON CONFLICT (team_id, admin_id) DO NOTHING
+	`, teamID, adminID)
+	return err
+}
+
+func (r *adminRepository) RemoveTeamCaptainRepo(ctx context.Context, teamID, adminID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM team_captains WHERE team_id = $1 AND admin_id = $2`, teamID, adminID)
+	return err
+}
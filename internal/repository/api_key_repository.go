@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type APIKeyRepository interface {
+	GetAPIKeysRepo(ctx context.Context) ([]models.APIKey, error)
+	GetAPIKeyByIDRepo(ctx context.Context, id string) (*models.APIKey, error)
+	CreateAPIKeyRepo(ctx context.Context, key *models.APIKey, keyHash string) error
+	RevokeAPIKeyRepo(ctx context.Context, id string) (*models.APIKey, error)
+	UseAPIKeyRepo(ctx context.Context, keyHash string) (*models.APIKey, error)
+	GetAPIKeyUsageRepo(ctx context.Context, id string, days int) ([]models.APIKeyUsage, error)
+}
+
+type apiKeyRepository struct {
+	db DBTX
+}
+
+func NewAPIKeyRepository(db DBTX) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, name, prefix, scopes, created_by, usage_count, last_used_at, revoked_at, created_at`
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	var key models.APIKey
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		pq.Array(&key.Scopes),
+		&key.CreatedBy,
+		&key.UsageCount,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetAPIKeysRepo(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		ORDER BY revoked_at IS NOT NULL, created_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, rows.Err()
+}
+
+func (r *apiKeyRepository) GetAPIKeyByIDRepo(ctx context.Context, id string) (*models.APIKey, error) {
+	return scanAPIKey(r.db.QueryRowContext(ctx, `
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE id = $1
+	`, id))
+}
+
+// CreateAPIKeyRepo stores a key by its hash, filling in its ID and timestamps
+func (r *apiKeyRepository) CreateAPIKeyRepo(ctx context.Context, key *models.APIKey, keyHash string) error {
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO api_keys (name, prefix, key_hash, scopes, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, usage_count, created_at
+	`,
+		key.Name,
+		key.Prefix,
+		keyHash,
+		pq.Array(key.Scopes),
+		key.CreatedBy,
+	).Scan(
+		&key.ID,
+		&key.UsageCount,
+		&key.CreatedAt,
+	)
+}
+
+// RevokeAPIKeyRepo revokes a key, keeping the time of a previous revocation,
+// and returns it; nil when it does not exist
+func (r *apiKeyRepository) RevokeAPIKeyRepo(ctx context.Context, id string) (*models.APIKey, error) {
+	return scanAPIKey(r.db.QueryRowContext(ctx, `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, now())
+		WHERE id = $1
+		RETURNING `+apiKeyColumns, id))
+}
+
+// UseAPIKeyRepo looks up the active key with keyHash and counts one request
+// against it, in total and for the day. Unknown and revoked keys return nil.
+func (r *apiKeyRepository) UseAPIKeyRepo(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	return scanAPIKey(r.db.QueryRowContext(ctx, `
+		WITH used AS (
+			UPDATE api_keys
+			SET usage_count = usage_count + 1, last_used_at = now()
+			WHERE key_hash = $1 AND revoked_at IS NULL
+			RETURNING `+apiKeyColumns+`
+		), daily AS (
+			INSERT INTO api_key_usage (api_key_id, usage_date, request_count)
+			SELECT id, CURRENT_DATE, 1 FROM used
+			ON CONFLICT (api_key_id, usage_date)
+			DO UPDATE SET request_count = api_key_usage.request_count + 1
+		)
+		SELECT `+apiKeyColumns+`
+		FROM used
+	`, keyHash))
+}
+
+// GetAPIKeyUsageRepo returns the daily request counts of a key over the last
+// days, most recent first
+func (r *apiKeyRepository) GetAPIKeyUsageRepo(ctx context.Context, id string, days int) ([]models.APIKeyUsage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT usage_date, request_count
+		FROM api_key_usage
+		WHERE api_key_id = $1 AND usage_date > CURRENT_DATE - $2::int
+		ORDER BY usage_date DESC
+	`, id, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []models.APIKeyUsage
+	for rows.Next() {
+		var day models.APIKeyUsage
+		if err := rows.Scan(&day.Date, &day.RequestCount); err != nil {
+			return nil, err
+		}
+		usage = append(usage, day)
+	}
+	return usage, rows.Err()
+}
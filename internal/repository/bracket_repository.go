@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type BracketRepository interface {
+	GetBracketsBySeasonRepo(ctx context.Context, seasonID string) ([]models.Bracket, error)
+	GetBracketByIDRepo(ctx context.Context, id string) (*models.Bracket, error)
+	CreateBracketRepo(ctx context.Context, bracket *models.Bracket) (*models.Bracket, error)
+	UpdateBracketStatusRepo(ctx context.Context, id, status string) error
+	GetBracketMatchesRepo(ctx context.Context, bracketID string) ([]models.BracketMatch, error)
+	GetBracketMatchByFixtureRepo(ctx context.Context, fixtureID string) (*models.BracketMatch, error)
+	LockBracketMatchRepo(ctx context.Context, bracketID string, round, position int, thirdPlace bool) (*models.BracketMatch, error)
+	CreateBracketMatchRepo(ctx context.Context, match *models.BracketMatch) (*models.BracketMatch, error)
+	UpdateBracketMatchRepo(ctx context.Context, match *models.BracketMatch) error
+	GetTeamsByLeaderboardRepo(ctx context.Context, seasonID string) ([]string, error)
+}
+
+type bracketRepository struct {
+	db DBTX
+}
+
+func NewBracketRepository(db DBTX) BracketRepository {
+	return &bracketRepository{db: db}
+}
+
+const bracketColumns = `id, season_id, name, size, entries, seed_source, third_place,
+	start_round, status, created_at, updated_at`
+
+func scanBracket(row rowScanner, bracket *models.Bracket) error {
+	return row.Scan(
+		&bracket.ID,
+		&bracket.SeasonID,
+		&bracket.Name,
+		&bracket.Size,
+		&bracket.Entries,
+		&bracket.SeedSource,
+		&bracket.ThirdPlace,
+		&bracket.StartRound,
+		&bracket.Status,
+		&bracket.CreatedAt,
+		&bracket.UpdatedAt,
+	)
+}
+
+func (r *bracketRepository) GetBracketsBySeasonRepo(ctx context.Context, seasonID string) ([]models.Bracket, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+bracketColumns+`
+		FROM brackets
+		WHERE season_id = $1
+		ORDER BY created_at ASC
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var brackets []models.Bracket
+	for rows.Next() {
+		var bracket models.Bracket
+		if err := scanBracket(rows, &bracket); err != nil {
+			return nil, err
+		}
+		brackets = append(brackets, bracket)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return brackets, nil
+}
+
+func (r *bracketRepository) GetBracketByIDRepo(ctx context.Context, id string) (*models.Bracket, error) {
+	var bracket models.Bracket
+	err := scanBracket(r.db.QueryRowContext(ctx, `
+		SELECT `+bracketColumns+`
+		FROM brackets
+		WHERE id = $1
+	`, id), &bracket)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &bracket, nil
+}
+
+func (r *bracketRepository) CreateBracketRepo(ctx context.Context, bracket *models.Bracket) (*models.Bracket, error) {
+	now := time.Now()
+	bracket.CreatedAt = now
+	bracket.UpdatedAt = now
+	bracket.Status = models.BracketStatusActive
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO brackets (season_id, name, size, entries, seed_source, third_place, start_round, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`, bracket.SeasonID, bracket.Name, bracket.Size, bracket.Entries, bracket.SeedSource,
+		bracket.ThirdPlace, bracket.StartRound, bracket.Status, bracket.CreatedAt, bracket.UpdatedAt,
+	).Scan(&bracket.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bracket, nil
+}
+
+func (r *bracketRepository) UpdateBracketStatusRepo(ctx context.Context, id, status string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE brackets
+		SET status = $1, updated_at = now()
+		WHERE id = $2
+	`, status, id)
+
+	return err
+}
+
+const bracketMatchColumns = `bm.id, bm.bracket_id, bm.round, bm.position, bm.third_place,
+	bm.home_seed, bm.guest_seed, bm.home_team_id, ht.name, bm.guest_team_id, gt.name,
+	bm.fixture_id, bm.winner_team_id, bm.created_at, bm.updated_at`
+
+const bracketMatchFrom = `
+	FROM bracket_matches bm
+	LEFT JOIN teams ht ON ht.id = bm.home_team_id
+	LEFT JOIN teams gt ON gt.id = bm.guest_team_id`
+
+func scanBracketMatch(row rowScanner, match *models.BracketMatch) error {
+	return row.Scan(
+		&match.ID,
+		&match.BracketID,
+		&match.Round,
+		&match.Position,
+		&match.ThirdPlace,
+		&match.HomeSeed,
+		&match.GuestSeed,
+		&match.HomeTeamID,
+		&match.HomeTeamName,
+		&match.GuestTeamID,
+		&match.GuestTeamName,
+		&match.FixtureID,
+		&match.WinnerTeamID,
+		&match.CreatedAt,
+		&match.UpdatedAt,
+	)
+}
+
+func (r *bracketRepository) GetBracketMatchesRepo(ctx context.Context, bracketID string) ([]models.BracketMatch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+bracketMatchColumns+bracketMatchFrom+`
+		WHERE bm.bracket_id = $1
+		ORDER BY bm.round ASC, bm.third_place ASC, bm.position ASC
+	`, bracketID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []models.BracketMatch
+	for rows.Next() {
+		var match models.BracketMatch
+		if err := scanBracketMatch(rows, &match); err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (r *bracketRepository) GetBracketMatchByFixtureRepo(ctx context.Context, fixtureID string) (*models.BracketMatch, error) {
+	var match models.BracketMatch
+	err := scanBracketMatch(r.db.QueryRowContext(ctx, `
+		SELECT `+bracketMatchColumns+bracketMatchFrom+`
+		WHERE bm.fixture_id = $1
+	`, fixtureID), &match)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+// LockBracketMatchRepo reads a bracket node with a row lock; it must run inside a transaction
+func (r *bracketRepository) LockBracketMatchRepo(ctx context.Context, bracketID string, round, position int, thirdPlace bool) (*models.BracketMatch, error) {
+	var match models.BracketMatch
+	err := scanBracketMatch(r.db.QueryRowContext(ctx, `
+		SELECT `+bracketMatchColumns+bracketMatchFrom+`
+		WHERE bm.bracket_id = $1 AND bm.round = $2 AND bm.position = $3 AND bm.third_place = $4
+		FOR UPDATE OF bm
+	`, bracketID, round, position, thirdPlace), &match)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+func (r *bracketRepository) CreateBracketMatchRepo(ctx context.Context, match *models.BracketMatch) (*models.BracketMatch, error) {
+	now := time.Now()
+	match.CreatedAt = now
+	match.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO bracket_matches (
+			bracket_id, round, position, third_place, home_seed, guest_seed,
+			home_team_id, guest_team_id, fixture_id, winner_team_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`, match.BracketID, match.Round, match.Position, match.ThirdPlace, match.HomeSeed, match.GuestSeed,
+		match.HomeTeamID, match.GuestTeamID, match.FixtureID, match.WinnerTeamID, match.CreatedAt, match.UpdatedAt,
+	).Scan(&match.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+func (r *bracketRepository) UpdateBracketMatchRepo(ctx context.Context, match *models.BracketMatch) error {
+	match.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE bracket_matches
+		SET home_seed = $1, guest_seed = $2, home_team_id = $3, guest_team_id = $4,
+			fixture_id = $5, winner_team_id = $6, updated_at = $7
+		WHERE id = $8
+	`, match.HomeSeed, match.GuestSeed, match.HomeTeamID, match.GuestTeamID,
+		match.FixtureID, match.WinnerTeamID, match.UpdatedAt, match.ID)
+
+	return err
+}
+
+// GetTeamsByLeaderboardRepo orders the teams of a season by the average
+// accumulated points of their active players
+func (r *bracketRepository) GetTeamsByLeaderboardRepo(ctx context.Context, seasonID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT t.id
+		FROM teams t
+		LEFT JOIN player_seasons ps ON ps.team_id = t.id AND ps.status = 'ACTIVE'
+		WHERE t.season_id = $1
+		GROUP BY t.id, t.name
+		ORDER BY COALESCE(AVG(ps.accumulated_points), 0) DESC, t.name ASC
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teamIDs []string
+	for rows.Next() {
+		var teamID string
+		if err := rows.Scan(&teamID); err != nil {
+			return nil, err
+		}
+		teamIDs = append(teamIDs, teamID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return teamIDs, nil
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type FixtureRepository interface {
+	GetFixturesBySeasonRepo(ctx context.Context, seasonID string, filter models.FixtureFilter) ([]models.Fixture, error)
+	GetFixtureByIDRepo(ctx context.Context, id string) (*models.Fixture, error)
+	LockFixtureRepo(ctx context.Context, id string) (*models.Fixture, error)
+	CreateFixtureRepo(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error)
+	UpdateFixtureRepo(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error)
+	DeleteFixturesBySeasonRepo(ctx context.Context, seasonID string) error
+	GetFixturesDueForLineupLockRepo(ctx context.Context, now time.Time) ([]models.Fixture, error)
+}
+
+type fixtureRepository struct {
+	db DBTX
+}
+
+func NewFixtureRepository(db DBTX) FixtureRepository {
+	return &fixtureRepository{db: db}
+}
+
+const fixtureColumns = `id, season_id, round, home_team_id, guest_team_id,
+	home_score, guest_score, status, lineup_deadline, created_at, updated_at`
+
+func scanFixture(row rowScanner, fixture *models.Fixture) error {
+	return row.Scan(
+		&fixture.ID,
+		&fixture.SeasonID,
+		&fixture.Round,
+		&fixture.HomeTeamID,
+		&fixture.GuestTeamID,
+		&fixture.HomeScore,
+		&fixture.GuestScore,
+		&fixture.Status,
+		&fixture.LineupDeadline,
+		&fixture.CreatedAt,
+		&fixture.UpdatedAt,
+	)
+}
+
+func (r *fixtureRepository) GetFixturesBySeasonRepo(ctx context.Context, seasonID string, filter models.FixtureFilter) ([]models.Fixture, error) {
+	conditions := []string{"season_id = $1"}
+	args := []interface{}{seasonID}
+
+	if filter.Round != nil {
+		args = append(args, *filter.Round)
+		conditions = append(conditions, fmt.Sprintf("round = $%d", len(args)))
+	}
+	if filter.TeamID != "" {
+		args = append(args, filter.TeamID)
+		conditions = append(conditions, fmt.Sprintf("(home_team_id = $%d OR guest_team_id = $%d)", len(args), len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+fixtureColumns+`
+		FROM fixtures
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY round ASC, created_at ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFixtures(rows)
+}
+
+// GetFixturesDueForLineupLockRepo returns the scheduled fixtures whose lineup
+// deadline has passed while a submitted lineup is still unlocked
+func (r *fixtureRepository) GetFixturesDueForLineupLockRepo(ctx context.Context, now time.Time) ([]models.Fixture, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+fixtureColumns+`
+		FROM fixtures f
+		WHERE f.lineup_deadline <= $1
+			AND f.status = $2
+			AND EXISTS (SELECT 1 FROM lineups l WHERE l.fixture_id = f.id AND l.status = $3)
+		ORDER BY f.lineup_deadline ASC
+	`, now, models.FixtureStatusScheduled, models.LineupStatusSubmitted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFixtures(rows)
+}
+
+func scanFixtures(rows *sql.Rows) ([]models.Fixture, error) {
+	var fixtures []models.Fixture
+	for rows.Next() {
+		var fixture models.Fixture
+		if err := scanFixture(rows, &fixture); err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fixtures, nil
+}
+
+func (r *fixtureRepository) GetFixtureByIDRepo(ctx context.Context, id string) (*models.Fixture, error) {
+	return r.getFixture(ctx, id, "")
+}
+
+// LockFixtureRepo reads a fixture with a row lock; it must run inside a transaction
+func (r *fixtureRepository) LockFixtureRepo(ctx context.Context, id string) (*models.Fixture, error) {
+	return r.getFixture(ctx, id, "FOR UPDATE")
+}
+
+func (r *fixtureRepository) getFixture(ctx context.Context, id string, lockClause string) (*models.Fixture, error) {
+	var fixture models.Fixture
+	err := scanFixture(r.db.QueryRowContext(ctx, `
+		SELECT `+fixtureColumns+`
+		FROM fixtures
+		WHERE id = $1
+		`+lockClause, id), &fixture)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &fixture, nil
+}
+
+func (r *fixtureRepository) CreateFixtureRepo(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error) {
+	now := time.Now()
+	fixture.CreatedAt = now
+	fixture.UpdatedAt = now
+
+	if fixture.Status == "" {
+		fixture.Status = models.FixtureStatusScheduled
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO fixtures (season_id, round, home_team_id, guest_team_id, status, lineup_deadline, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, home_score, guest_score
+	`, fixture.SeasonID, fixture.Round, fixture.HomeTeamID, fixture.GuestTeamID,
+		fixture.Status, fixture.LineupDeadline, fixture.CreatedAt, fixture.UpdatedAt,
+	).Scan(&fixture.ID, &fixture.HomeScore, &fixture.GuestScore)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fixture, nil
+}
+
+func (r *fixtureRepository) UpdateFixtureRepo(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error) {
+	fixture.UpdatedAt = time.Now()
+
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE fixtures
+		SET round = $1, home_team_id = $2, guest_team_id = $3,
+			home_score = $4, guest_score = $5, status = $6, lineup_deadline = $7, updated_at = $8
+		WHERE id = $9
+		RETURNING created_at
+	`, fixture.Round, fixture.HomeTeamID, fixture.GuestTeamID,
+		fixture.HomeScore, fixture.GuestScore, fixture.Status, fixture.LineupDeadline, fixture.UpdatedAt, fixture.ID,
+	).Scan(&fixture.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fixture, nil
+}
+
+// DeleteFixturesBySeasonRepo removes the regular season fixtures. Playoff
+// fixtures are owned by their bracket and never deleted here; the schedule
+// service also refuses to regenerate once a season has a bracket.
+func (r *fixtureRepository) DeleteFixturesBySeasonRepo(ctx context.Context, seasonID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM fixtures f
+		WHERE f.season_id = $1
+			AND NOT EXISTS (SELECT 1 FROM bracket_matches bm WHERE bm.fixture_id = f.id)
+	`, seasonID)
+	return err
+}
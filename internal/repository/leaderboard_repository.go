@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type LeaderboardRepository interface {
+	GetLeaderboardRepo(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, int, error)
+	GetMaterializedAtRepo(ctx context.Context) (*time.Time, error)
+	GetPreviousSnapshotRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*int, error)
+	GetRoundLeaderboardRepo(ctx context.Context, filter models.RoundLeaderboardFilter, previousRound *int) ([]models.RoundLeaderboardEntry, int, error)
+}
+
+type leaderboardRepository struct {
+	db DBTX
+}
+
+func NewLeaderboardRepository(db DBTX) LeaderboardRepository {
+	return &leaderboardRepository{db: db}
+}
+
+// Both sources expose the same columns so one query serves the materialized
+// leaderboard and the live view
+const (
+	materializedLeaderboardSource = `
+		SELECT player_season_id, season_id, player_id, player_name, avatar_url,
+			team_id, team_name, rank_id, rank_name, accumulated_points
+		FROM mat_season_leaderboard`
+	liveLeaderboardSource = `
+		SELECT player_season_id, season_id, player_id, full_name AS player_name, avatar_url,
+			team_id, team_name, rank_id, rank_name, accumulated_points
+		FROM v_season_leaderboard`
+)
+
+// GetLeaderboardRepo returns one page of the leaderboard and the total number
+// of rows matching the filter. Positions are computed over the whole season
+// before the team/rank filters so a filtered row keeps its overall position.
+func (r *leaderboardRepository) GetLeaderboardRepo(ctx context.Context, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, int, error) {
+	source := materializedLeaderboardSource
+	if filter.Fresh {
+		source = liveLeaderboardSource
+	}
+
+	ranked := `
+		SELECT *
+		FROM (
+			SELECT lb.*, RANK() OVER (ORDER BY lb.accumulated_points DESC) AS position
+			FROM (` + source + `) lb
+			WHERE lb.season_id = $1
+		) ranked
+		WHERE ($2 = '' OR team_id::text = $2)
+			AND ($3 = '' OR rank_id = $3)`
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+ranked+`) filtered`,
+		filter.SeasonID, filter.TeamID, filter.RankID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT position, player_season_id, player_id, player_name, avatar_url,
+			team_id, team_name, rank_id, rank_name, accumulated_points
+		FROM (`+ranked+`) filtered
+		ORDER BY position ASC, player_name ASC
+		LIMIT $4 OFFSET $5
+	`, filter.SeasonID, filter.TeamID, filter.RankID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []models.LeaderboardEntry
+	for rows.Next() {
+		var entry models.LeaderboardEntry
+		err := rows.Scan(
+			&entry.Position,
+			&entry.PlayerSeasonID,
+			&entry.PlayerID,
+			&entry.PlayerName,
+			&entry.AvatarURL,
+			&entry.TeamID,
+			&entry.TeamName,
+			&entry.RankID,
+			&entry.RankName,
+			&entry.AccumulatedPoints,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// GetMaterializedAtRepo returns when mat_season_leaderboard was last refreshed
+func (r *leaderboardRepository) GetMaterializedAtRepo(ctx context.Context) (*time.Time, error) {
+	var materializedAt time.Time
+	err := r.db.QueryRowContext(ctx, `
+		SELECT materialized_at
+		FROM mat_season_leaderboard
+		LIMIT 1
+	`).Scan(&materializedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &materializedAt, nil
+}
+
+// GetPreviousSnapshotRoundRepo returns the latest round before roundNumber
+// that has a standings snapshot, or nil when roundNumber is the first one
+func (r *leaderboardRepository) GetPreviousSnapshotRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*int, error) {
+	var previous sql.NullInt64
+	err := r.db.QueryRowContext(ctx, `
+		SELECT MAX(round_number)
+		FROM player_round_standings
+		WHERE season_id = $1 AND round_number < $2
+	`, seasonID, roundNumber).Scan(&previous)
+	if err != nil {
+		return nil, err
+	}
+
+	if !previous.Valid {
+		return nil, nil
+	}
+	round := int(previous.Int64)
+	return &round, nil
+}
+
+// GetRoundLeaderboardRepo returns one page of the standings snapshot of a
+// round with each player's position in the previous snapshot. Team and rank
+// are the ones the player had when the round was finalized.
+func (r *leaderboardRepository) GetRoundLeaderboardRepo(ctx context.Context, filter models.RoundLeaderboardFilter, previousRound *int) ([]models.RoundLeaderboardEntry, int, error) {
+	snapshot := `
+		SELECT *
+		FROM player_round_standings
+		WHERE season_id = $1 AND round_number = $2
+			AND ($3 = '' OR team_id::text = $3)
+			AND ($4 = '' OR rank_id = $4)`
+
+	var total int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (`+snapshot+`) filtered`,
+		filter.SeasonID, filter.RoundNumber, filter.TeamID, filter.RankID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			prs.rank_position,
+			prs.player_season_id,
+			p.id,
+			p.full_name,
+			p.avatar_url,
+			COALESCE(prs.team_id::text, ''),
+			COALESCE(t.name, ''),
+			COALESCE(prs.rank_id, ''),
+			rk.description,
+			prs.accumulated_points,
+			prev.rank_position
+		FROM (`+snapshot+`) prs
+		JOIN player_seasons ps ON ps.id = prs.player_season_id
+		JOIN players p ON p.id = ps.player_id
+		LEFT JOIN teams t ON t.id = prs.team_id
+		LEFT JOIN ranks rk ON rk.id = prs.rank_id
+		LEFT JOIN player_round_standings prev
+			ON prev.player_season_id = prs.player_season_id AND prev.round_number = $5
+		ORDER BY prs.rank_position ASC, p.full_name ASC
+		LIMIT $6 OFFSET $7
+	`, filter.SeasonID, filter.RoundNumber, filter.TeamID, filter.RankID, previousRound, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []models.RoundLeaderboardEntry
+	for rows.Next() {
+		var entry models.RoundLeaderboardEntry
+		err := rows.Scan(
+			&entry.Position,
+			&entry.PlayerSeasonID,
+			&entry.PlayerID,
+			&entry.PlayerName,
+			&entry.AvatarURL,
+			&entry.TeamID,
+			&entry.TeamName,
+			&entry.RankID,
+			&entry.RankName,
+			&entry.AccumulatedPoints,
+			&entry.PreviousPosition,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
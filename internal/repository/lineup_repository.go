@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type LineupRepository interface {
+	GetLineupsByFixtureRepo(ctx context.Context, fixtureID string) ([]models.Lineup, error)
+	SaveLineupRepo(ctx context.Context, lineup *models.Lineup) (*models.Lineup, error)
+	LockLineupsRepo(ctx context.Context, fixtureID string) error
+}
+
+type lineupRepository struct {
+	db DBTX
+}
+
+func NewLineupRepository(db DBTX) LineupRepository {
+	return &lineupRepository{db: db}
+}
+
+// GetLineupsByFixtureRepo returns the lineups of a fixture with their entries
+// ordered by match order
+func (r *lineupRepository) GetLineupsByFixtureRepo(ctx context.Context, fixtureID string) ([]models.Lineup, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, fixture_id, team_id, status, submitted_at, locked_at, created_at, updated_at
+		FROM lineups
+		WHERE fixture_id = $1
+		ORDER BY submitted_at ASC
+	`, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lineups []models.Lineup
+	index := make(map[string]int)
+	for rows.Next() {
+		lineup := models.Lineup{Entries: []models.LineupEntry{}}
+		err := rows.Scan(
+			&lineup.ID,
+			&lineup.FixtureID,
+			&lineup.TeamID,
+			&lineup.Status,
+			&lineup.SubmittedAt,
+			&lineup.LockedAt,
+			&lineup.CreatedAt,
+			&lineup.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		index[lineup.ID] = len(lineups)
+		lineups = append(lineups, lineup)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	entryRows, err := r.db.QueryContext(ctx, `
+		SELECT e.lineup_id, e.match_order, e.match_type, e.player1_id, e.player2_id
+		FROM lineup_entries e
+		JOIN lineups l ON l.id = e.lineup_id
+		WHERE l.fixture_id = $1
+		ORDER BY e.match_order ASC
+	`, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var lineupID string
+		var entry models.LineupEntry
+		err := entryRows.Scan(
+			&lineupID,
+			&entry.MatchOrder,
+			&entry.MatchType,
+			&entry.Player1ID,
+			&entry.Player2ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := index[lineupID]; ok {
+			lineups[i].Entries = append(lineups[i].Entries, entry)
+		}
+	}
+
+	if err = entryRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lineups, nil
+}
+
+// SaveLineupRepo creates or replaces the lineup of the team for the fixture;
+// it must run inside a transaction
+func (r *lineupRepository) SaveLineupRepo(ctx context.Context, lineup *models.Lineup) (*models.Lineup, error) {
+	now := time.Now()
+	lineup.Status = models.LineupStatusSubmitted
+	lineup.SubmittedAt = now
+	lineup.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO lineups (fixture_id, team_id, status, submitted_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4, $4)
+		ON CONFLICT (fixture_id, team_id) DO UPDATE
+		SET status = EXCLUDED.status,
+			submitted_at = EXCLUDED.submitted_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at
+	`, lineup.FixtureID, lineup.TeamID, lineup.Status, now).Scan(&lineup.ID, &lineup.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM lineup_entries WHERE lineup_id = $1`, lineup.ID); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range lineup.Entries {
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO lineup_entries (lineup_id, match_order, match_type, player1_id, player2_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, lineup.ID, entry.MatchOrder, entry.MatchType, entry.Player1ID, entry.Player2ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lineup, nil
+}
+
+// LockLineupsRepo locks every submitted lineup of the fixture
+func (r *lineupRepository) LockLineupsRepo(ctx context.Context, fixtureID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE lineups
+		SET status = $1, locked_at = now(), updated_at = now()
+		WHERE fixture_id = $2 AND status = $3
+	`, models.LineupStatusLocked, fixtureID, models.LineupStatusSubmitted)
+
+	return err
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type MatchRepository interface {
+	GetMatchesByFixtureRepo(ctx context.Context, fixtureID string) ([]models.Match, error)
+	GetMatchByIDRepo(ctx context.Context, id string) (*models.Match, error)
+	GetMatchByOrderRepo(ctx context.Context, fixtureID string, matchOrder int) (*models.Match, error)
+	CreateMatchRepo(ctx context.Context, match *models.Match) (*models.Match, error)
+	UpdateMatchResultRepo(ctx context.Context, match *models.Match) (*models.Match, error)
+	GetMatchAmendmentsRepo(ctx context.Context, matchID string) ([]models.MatchAmendment, error)
+	CreateMatchAmendmentRepo(ctx context.Context, amendment *models.MatchAmendment) (*models.MatchAmendment, error)
+}
+
+type matchRepository struct {
+	db DBTX
+}
+
+func NewMatchRepository(db DBTX) MatchRepository {
+	return &matchRepository{db: db}
+}
+
+const matchColumns = `
+	id, fixture_id, match_order, match_type,
+	home_player1_id, home_player2_id, guest_player1_id, guest_player2_id,
+	handicap_snapshot, rank_snapshot, point_before, point_after,
+	home_sets, guest_sets, winner_team_id, result_type, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMatch(row rowScanner) (*models.Match, error) {
+	var match models.Match
+	err := row.Scan(
+		&match.ID,
+		&match.FixtureID,
+		&match.MatchOrder,
+		&match.MatchType,
+		&match.HomePlayer1ID,
+		&match.HomePlayer2ID,
+		&match.GuestPlayer1ID,
+		&match.GuestPlayer2ID,
+		&match.HandicapSnapshot,
+		&match.RankSnapshot,
+		&match.PointBefore,
+		&match.PointAfter,
+		pq.Array(&match.HomeSets),
+		pq.Array(&match.GuestSets),
+		&match.WinnerTeamID,
+		&match.ResultType,
+		&match.CreatedAt,
+		&match.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *matchRepository) GetMatchesByFixtureRepo(ctx context.Context, fixtureID string) ([]models.Match, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+matchColumns+`
+		FROM matches
+		WHERE fixture_id = $1
+		ORDER BY match_order ASC
+	`, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []models.Match
+	for rows.Next() {
+		match, err := scanMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, *match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+func (r *matchRepository) GetMatchByIDRepo(ctx context.Context, id string) (*models.Match, error) {
+	match, err := scanMatch(r.db.QueryRowContext(ctx, `
+		SELECT `+matchColumns+`
+		FROM matches
+		WHERE id = $1
+	`, id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return match, err
+}
+
+func (r *matchRepository) GetMatchByOrderRepo(ctx context.Context, fixtureID string, matchOrder int) (*models.Match, error) {
+	match, err := scanMatch(r.db.QueryRowContext(ctx, `
+		SELECT `+matchColumns+`
+		FROM matches
+		WHERE fixture_id = $1 AND match_order = $2
+	`, fixtureID, matchOrder))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return match, err
+}
+
+func (r *matchRepository) CreateMatchRepo(ctx context.Context, match *models.Match) (*models.Match, error) {
+	now := time.Now()
+	match.CreatedAt = now
+	match.UpdatedAt = now
+
+	if match.ResultType == "" {
+		match.ResultType = models.ResultTypeNormal
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO matches (
+			fixture_id, match_order, match_type,
+			home_player1_id, home_player2_id, guest_player1_id, guest_player2_id,
+			handicap_snapshot, rank_snapshot, point_before, point_after,
+			home_sets, guest_sets, winner_team_id, result_type, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING id
+	`,
+		match.FixtureID,
+		match.MatchOrder,
+		match.MatchType,
+		match.HomePlayer1ID,
+		match.HomePlayer2ID,
+		match.GuestPlayer1ID,
+		match.GuestPlayer2ID,
+		match.HandicapSnapshot,
+		match.RankSnapshot,
+		match.PointBefore,
+		match.PointAfter,
+		pq.Array(match.HomeSets),
+		pq.Array(match.GuestSets),
+		match.WinnerTeamID,
+		match.ResultType,
+		match.CreatedAt,
+		match.UpdatedAt,
+	).Scan(&match.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+// UpdateMatchResultRepo records the result and snapshots of an existing rubber,
+// one pre-filled from the lineups or one being amended
+func (r *matchRepository) UpdateMatchResultRepo(ctx context.Context, match *models.Match) (*models.Match, error) {
+	match.UpdatedAt = time.Now()
+
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE matches
+		SET handicap_snapshot = $1, rank_snapshot = $2, point_before = $3, point_after = $4,
+			home_sets = $5, guest_sets = $6, winner_team_id = $7, result_type = $8, updated_at = $9
+		WHERE id = $10
+		RETURNING created_at
+	`,
+		match.HandicapSnapshot,
+		match.RankSnapshot,
+		match.PointBefore,
+		match.PointAfter,
+		pq.Array(match.HomeSets),
+		pq.Array(match.GuestSets),
+		match.WinnerTeamID,
+		match.ResultType,
+		match.UpdatedAt,
+		match.ID,
+	).Scan(&match.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+// GetMatchAmendmentsRepo returns the amendments of a rubber, latest first
+func (r *matchRepository) GetMatchAmendmentsRepo(ctx context.Context, matchID string) ([]models.MatchAmendment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, match_id, reason,
+			old_home_sets, old_guest_sets, old_winner_team_id, old_result_type,
+			new_home_sets, new_guest_sets, new_winner_team_id, new_result_type,
+			created_at
+		FROM match_amendments
+		WHERE match_id = $1
+		ORDER BY created_at DESC
+	`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var amendments []models.MatchAmendment
+	for rows.Next() {
+		var amendment models.MatchAmendment
+		if err := rows.Scan(
+			&amendment.ID,
+			&amendment.MatchID,
+			&amendment.Reason,
+			pq.Array(&amendment.Before.HomeSets),
+			pq.Array(&amendment.Before.GuestSets),
+			&amendment.Before.WinnerTeamID,
+			&amendment.Before.ResultType,
+			pq.Array(&amendment.After.HomeSets),
+			pq.Array(&amendment.After.GuestSets),
+			&amendment.After.WinnerTeamID,
+			&amendment.After.ResultType,
+			&amendment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		amendments = append(amendments, amendment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return amendments, nil
+}
+
+func (r *matchRepository) CreateMatchAmendmentRepo(ctx context.Context, amendment *models.MatchAmendment) (*models.MatchAmendment, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO match_amendments (
+			match_id, reason,
+			old_home_sets, old_guest_sets, old_winner_team_id, old_result_type,
+			new_home_sets, new_guest_sets, new_winner_team_id, new_result_type
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`,
+		amendment.MatchID,
+		amendment.Reason,
+		pq.Array(amendment.Before.HomeSets),
+		pq.Array(amendment.Before.GuestSets),
+		amendment.Before.WinnerTeamID,
+		amendment.Before.ResultType,
+		pq.Array(amendment.After.HomeSets),
+		pq.Array(amendment.After.GuestSets),
+		amendment.After.WinnerTeamID,
+		amendment.After.ResultType,
+	).Scan(&amendment.ID, &amendment.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return amendment, nil
+}
@@ -4,14 +4,16 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/lib/pq"
+
 	"backend-ping-pong-app/internal/models"
 )
 
 type playerRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewPlayerRepository(db *sql.DB) PlayerRepository {
+func NewPlayerRepository(db DBTX) PlayerRepository {
 	return &playerRepository{db: db}
 }
 
@@ -19,6 +21,35 @@ type PlayerRepository interface {
 	GetAllPlayerRepo(ctx context.Context) ([]models.PlayerListResponse, error)
 	SearchByNameRepo(ctx context.Context, name string) ([]models.PlayerListResponse, error)
 	CreatePlayerRepo(ctx context.Context, p *models.Player) error
+	GetByID(ctx context.Context, id string) (*models.Player, error)
+	GetPlayerByFirebaseUIDRepo(ctx context.Context, uid string) (*models.Player, error)
+	GetUnlinkedPlayersByPhoneRepo(ctx context.Context, phones []string) ([]models.Player, error)
+	LinkFirebaseUIDRepo(ctx context.Context, playerID string, uid *string) (bool, error)
+	UpdatePlayerProfileRepo(ctx context.Context, id string, update models.PlayerProfileUpdate) (*models.Player, error)
+}
+
+const playerColumns = `id, full_name, birth_year, phone, cccd, avatar_url, firebase_uid, is_active, created_at`
+
+func scanPlayer(row rowScanner) (*models.Player, error) {
+	var p models.Player
+	err := row.Scan(
+		&p.ID,
+		&p.FullName,
+		&p.BirthYear,
+		&p.Phone,
+		&p.CCCD,
+		&p.AvatarURL,
+		&p.FirebaseUID,
+		&p.IsActive,
+		&p.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
 }
 
 func (r *playerRepository) GetAllPlayerRepo(ctx context.Context) ([]models.PlayerListResponse, error) {
@@ -53,36 +84,74 @@ func (r *playerRepository) GetAllPlayerRepo(ctx context.Context) ([]models.Playe
 }
 
 func (r *playerRepository) GetByID(ctx context.Context, id string) (*models.Player, error) {
-	var p models.Player
-
-	err := r.db.QueryRowContext(ctx, `
-		SELECT 
-			id,
-			full_name,
-			birth_year,
-			phone,
-			cccd,
-			avatar_url,
-			is_active,
-			created_at
+	return scanPlayer(r.db.QueryRowContext(ctx, `
+		SELECT `+playerColumns+`
 		FROM players
 		WHERE id = $1
-	`, id).Scan(
-		&p.ID,
-		&p.FullName,
-		&p.BirthYear,
-		&p.Phone,
-		&p.CCCD,
-		&p.AvatarURL,
-		&p.IsActive,
-		&p.CreatedAt,
-	)
+	`, id))
+}
+
+func (r *playerRepository) GetPlayerByFirebaseUIDRepo(ctx context.Context, uid string) (*models.Player, error) {
+	return scanPlayer(r.db.QueryRowContext(ctx, `
+		SELECT `+playerColumns+`
+		FROM players
+		WHERE firebase_uid = $1
+	`, uid))
+}
 
+// GetUnlinkedPlayersByPhoneRepo finds the players without a mobile account
+// whose phone, stripped to its digits, is one of phones
+func (r *playerRepository) GetUnlinkedPlayersByPhoneRepo(ctx context.Context, phones []string) ([]models.Player, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+playerColumns+`
+		FROM players
+		WHERE firebase_uid IS NULL
+			AND regexp_replace(phone, '[^0-9]', '', 'g') = ANY($1)
+	`, pq.Array(phones))
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return &p, nil
+	var players []models.Player
+	for rows.Next() {
+		p, err := scanPlayer(rows)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, *p)
+	}
+	return players, rows.Err()
+}
+
+// LinkFirebaseUIDRepo sets or, with a nil uid, clears the mobile account of a
+// player and reports whether the player exists
+func (r *playerRepository) LinkFirebaseUIDRepo(ctx context.Context, playerID string, uid *string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE players SET firebase_uid = $2 WHERE id = $1`, playerID, uid)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// UpdatePlayerProfileRepo applies the non-nil fields of update and returns
+// the player, nil when it does not exist
+func (r *playerRepository) UpdatePlayerProfileRepo(ctx context.Context, id string, update models.PlayerProfileUpdate) (*models.Player, error) {
+	return scanPlayer(r.db.QueryRowContext(ctx, `
+		UPDATE players SET
+			full_name = COALESCE($2, full_name),
+			birth_year = COALESCE($3, birth_year),
+			phone = COALESCE($4, phone),
+			avatar_url = COALESCE($5, avatar_url)
+		WHERE id = $1
+		RETURNING `+playerColumns,
+		id,
+		update.FullName,
+		update.BirthYear,
+		update.Phone,
+		update.AvatarURL,
+	))
 }
 
 func (r *playerRepository) SearchByNameRepo(ctx context.Context, name string) ([]models.PlayerListResponse, error) {
@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type PlayerSeasonRepository interface {
+	GetPlayerSeasonsBySeasonRepo(ctx context.Context, seasonID string) ([]models.PlayerSeason, error)
+	GetRosterRepo(ctx context.Context, seasonID string) ([]models.RosterEntry, error)
+	GetPlayerSeasonByIDRepo(ctx context.Context, id string) (*models.PlayerSeason, error)
+	LockPlayerSeasonRepo(ctx context.Context, id string) (*models.PlayerSeason, error)
+	GetPlayerSeasonByPlayerRepo(ctx context.Context, seasonID, playerID string) (*models.PlayerSeason, error)
+	AddAccumulatedPointsRepo(ctx context.Context, id string, delta float64) (float64, error)
+	UpdatePlayerSeasonRankRepo(ctx context.Context, id, rankID string) error
+	UpdatePlayerSeasonTeamRepo(ctx context.Context, id, teamID string) error
+	CreatePlayerSeasonRepo(ctx context.Context, ps *models.PlayerSeason) (*models.PlayerSeason, error)
+	UpdatePlayerSeasonStatusRepo(ctx context.Context, id, status string) error
+	UpdateDisplayOrderRepo(ctx context.Context, id string, displayOrder int) error
+}
+
+type playerSeasonRepository struct {
+	db DBTX
+}
+
+func NewPlayerSeasonRepository(db DBTX) PlayerSeasonRepository {
+	return &playerSeasonRepository{db: db}
+}
+
+const playerSeasonColumns = `
+	id, season_id, player_id, team_id, rank_id, accumulated_points,
+	status, display_order, created_at, updated_at`
+
+func scanPlayerSeason(row rowScanner) (*models.PlayerSeason, error) {
+	var ps models.PlayerSeason
+	err := row.Scan(
+		&ps.ID,
+		&ps.SeasonID,
+		&ps.PlayerID,
+		&ps.TeamID,
+		&ps.RankID,
+		&ps.AccumulatedPoints,
+		&ps.Status,
+		&ps.DisplayOrder,
+		&ps.CreatedAt,
+		&ps.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ps, nil
+}
+
+func (r *playerSeasonRepository) GetPlayerSeasonsBySeasonRepo(ctx context.Context, seasonID string) ([]models.PlayerSeason, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+playerSeasonColumns+`
+		FROM player_seasons
+		WHERE season_id = $1
+		ORDER BY display_order ASC NULLS LAST, created_at ASC
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var playerSeasons []models.PlayerSeason
+	for rows.Next() {
+		ps, err := scanPlayerSeason(rows)
+		if err != nil {
+			return nil, err
+		}
+		playerSeasons = append(playerSeasons, *ps)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return playerSeasons, nil
+}
+
+func (r *playerSeasonRepository) GetRosterRepo(ctx context.Context, seasonID string) ([]models.RosterEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			ps.id, ps.season_id, ps.player_id, ps.team_id, ps.rank_id, ps.accumulated_points,
+			ps.status, ps.display_order, ps.created_at, ps.updated_at,
+			p.full_name, p.avatar_url, t.name
+		FROM player_seasons ps
+		JOIN players p ON p.id = ps.player_id
+		JOIN teams t ON t.id = ps.team_id
+		WHERE ps.season_id = $1
+		ORDER BY ps.display_order ASC NULLS LAST, p.full_name ASC
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roster []models.RosterEntry
+	for rows.Next() {
+		var entry models.RosterEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.SeasonID,
+			&entry.PlayerID,
+			&entry.TeamID,
+			&entry.RankID,
+			&entry.AccumulatedPoints,
+			&entry.Status,
+			&entry.DisplayOrder,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+			&entry.PlayerName,
+			&entry.AvatarURL,
+			&entry.TeamName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		roster = append(roster, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roster, nil
+}
+
+func (r *playerSeasonRepository) GetPlayerSeasonByIDRepo(ctx context.Context, id string) (*models.PlayerSeason, error) {
+	return r.getPlayerSeason(ctx, id, "")
+}
+
+// LockPlayerSeasonRepo reads a player season with a row lock; it must run inside a transaction
+func (r *playerSeasonRepository) LockPlayerSeasonRepo(ctx context.Context, id string) (*models.PlayerSeason, error) {
+	return r.getPlayerSeason(ctx, id, "FOR UPDATE")
+}
+
+func (r *playerSeasonRepository) getPlayerSeason(ctx context.Context, id string, lockClause string) (*models.PlayerSeason, error) {
+	ps, err := scanPlayerSeason(r.db.QueryRowContext(ctx, `
+		SELECT `+playerSeasonColumns+`
+		FROM player_seasons
+		WHERE id = $1
+		`+lockClause, id))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return ps, err
+}
+
+func (r *playerSeasonRepository) GetPlayerSeasonByPlayerRepo(ctx context.Context, seasonID, playerID string) (*models.PlayerSeason, error) {
+	ps, err := scanPlayerSeason(r.db.QueryRowContext(ctx, `
+		SELECT `+playerSeasonColumns+`
+		FROM player_seasons
+		WHERE season_id = $1 AND player_id = $2
+	`, seasonID, playerID))
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return ps, err
+}
+
+// AddAccumulatedPointsRepo adds delta to a player's season points and returns the new total
+func (r *playerSeasonRepository) AddAccumulatedPointsRepo(ctx context.Context, id string, delta float64) (float64, error) {
+	var total float64
+	err := r.db.QueryRowContext(ctx, `
+		UPDATE player_seasons
+		SET accumulated_points = accumulated_points + $1, updated_at = now()
+		WHERE id = $2
+		RETURNING accumulated_points
+	`, delta, id).Scan(&total)
+
+	return total, err
+}
+
+func (r *playerSeasonRepository) UpdatePlayerSeasonRankRepo(ctx context.Context, id, rankID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_seasons
+		SET rank_id = $1, updated_at = now()
+		WHERE id = $2
+	`, rankID, id)
+
+	return err
+}
+
+// CreatePlayerSeasonRepo registers a player in a season; when no display order
+// is given the player is appended after the current roster
+func (r *playerSeasonRepository) CreatePlayerSeasonRepo(ctx context.Context, ps *models.PlayerSeason) (*models.PlayerSeason, error) {
+	now := time.Now()
+	ps.CreatedAt = now
+	ps.UpdatedAt = now
+
+	if ps.Status == "" {
+		ps.Status = models.PlayerSeasonStatusActive
+	}
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO player_seasons (
+			season_id, player_id, team_id, rank_id, accumulated_points,
+			status, display_order, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6,
+			COALESCE($7, (SELECT COALESCE(MAX(display_order), 0) + 1 FROM player_seasons WHERE season_id = $1)),
+			$8, $9
+		)
+		RETURNING id, display_order
+	`, ps.SeasonID, ps.PlayerID, ps.TeamID, ps.RankID, ps.AccumulatedPoints,
+		ps.Status, ps.DisplayOrder, ps.CreatedAt, ps.UpdatedAt,
+	).Scan(&ps.ID, &ps.DisplayOrder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+func (r *playerSeasonRepository) UpdatePlayerSeasonStatusRepo(ctx context.Context, id, status string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_seasons
+		SET status = $1, updated_at = now()
+		WHERE id = $2
+	`, status, id)
+
+	return err
+}
+
+func (r *playerSeasonRepository) UpdateDisplayOrderRepo(ctx context.Context, id string, displayOrder int) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_seasons
+		SET display_order = $1, updated_at = now()
+		WHERE id = $2
+	`, displayOrder, id)
+
+	return err
+}
+
+func (r *playerSeasonRepository) UpdatePlayerSeasonTeamRepo(ctx context.Context, id, teamID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_seasons
+		SET team_id = $1, updated_at = now()
+		WHERE id = $2
+	`, teamID, id)
+
+	return err
+}
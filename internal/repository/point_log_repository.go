@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type PointLogRepository interface {
+	CreatePointLogRepo(ctx context.Context, log *models.PointLog) (*models.PointLog, error)
+	GetPointLogsByRefRepo(ctx context.Context, refID string) ([]models.PointLog, error)
+	GetPointHistoryRepo(ctx context.Context, playerSeasonID string) ([]models.PointHistoryEntry, error)
+}
+
+type pointLogRepository struct {
+	db DBTX
+}
+
+func NewPointLogRepository(db DBTX) PointLogRepository {
+	return &pointLogRepository{db: db}
+}
+
+func (r *pointLogRepository) CreatePointLogRepo(ctx context.Context, log *models.PointLog) (*models.PointLog, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO player_point_logs (player_season_id, delta_points, reason, source, ref_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, log.PlayerSeasonID, log.DeltaPoints, log.Reason, log.Source, log.RefID).Scan(&log.ID, &log.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// GetPointLogsByRefRepo returns every point log referencing refID, oldest first
+func (r *pointLogRepository) GetPointLogsByRefRepo(ctx context.Context, refID string) ([]models.PointLog, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, player_season_id, delta_points, reason, source, ref_id, created_at
+		FROM player_point_logs
+		WHERE ref_id = $1
+		ORDER BY created_at ASC
+	`, refID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []models.PointLog
+	for rows.Next() {
+		var log models.PointLog
+		if err := rows.Scan(
+			&log.ID,
+			&log.PlayerSeasonID,
+			&log.DeltaPoints,
+			&log.Reason,
+			&log.Source,
+			&log.RefID,
+			&log.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// GetPointHistoryRepo returns every point log of a player season, oldest
+// first. A MATCH log is applied once the round of its rubber is finalized;
+// every other source is applied when it is written.
+func (r *pointLogRepository) GetPointHistoryRepo(ctx context.Context, playerSeasonID string) ([]models.PointHistoryEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			l.id, l.player_season_id, l.delta_points, l.reason, l.source, l.ref_id, l.created_at,
+			CASE WHEN l.source = 'MATCH' THEN EXISTS (
+				SELECT 1
+				FROM matches m
+				JOIN fixtures f ON f.id = m.fixture_id
+				JOIN rounds rd ON rd.season_id = f.season_id AND rd.round_number = f.round
+				WHERE m.id = l.ref_id AND rd.status = 'FINALIZED'
+			) ELSE TRUE END
+		FROM player_point_logs l
+		WHERE l.player_season_id = $1
+		ORDER BY l.created_at ASC, l.id ASC
+	`, playerSeasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.PointHistoryEntry
+	for rows.Next() {
+		var entry models.PointHistoryEntry
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.PlayerSeasonID,
+			&entry.DeltaPoints,
+			&entry.Reason,
+			&entry.Source,
+			&entry.RefID,
+			&entry.CreatedAt,
+			&entry.Applied,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
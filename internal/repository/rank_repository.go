@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type RankRepository interface {
+	GetAllRanksRepo(ctx context.Context) ([]models.Rank, error)
+	GetRankByIDRepo(ctx context.Context, id string) (*models.Rank, error)
+	CreateRankHistoryRepo(ctx context.Context, change *models.RankChange) (*models.RankChange, error)
+	GetRankHistoryRepo(ctx context.Context, seasonID, playerID string) ([]models.RankChange, error)
+}
+
+type rankRepository struct {
+	db DBTX
+}
+
+func NewRankRepository(db DBTX) RankRepository {
+	return &rankRepository{db: db}
+}
+
+func (r *rankRepository) GetAllRanksRepo(ctx context.Context) ([]models.Rank, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, sort_order, min_score, max_score, standard_score, k_factor, description
+		FROM ranks
+		ORDER BY sort_order ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ranks []models.Rank
+	for rows.Next() {
+		var rank models.Rank
+		err := rows.Scan(
+			&rank.ID,
+			&rank.SortOrder,
+			&rank.MinScore,
+			&rank.MaxScore,
+			&rank.StandardScore,
+			&rank.KFactor,
+			&rank.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+		ranks = append(ranks, rank)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ranks, nil
+}
+
+func (r *rankRepository) GetRankByIDRepo(ctx context.Context, id string) (*models.Rank, error) {
+	var rank models.Rank
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, sort_order, min_score, max_score, standard_score, k_factor, description
+		FROM ranks
+		WHERE id = $1
+	`, id).Scan(
+		&rank.ID,
+		&rank.SortOrder,
+		&rank.MinScore,
+		&rank.MaxScore,
+		&rank.StandardScore,
+		&rank.KFactor,
+		&rank.Description,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rank, nil
+}
+
+func (r *rankRepository) CreateRankHistoryRepo(ctx context.Context, change *models.RankChange) (*models.RankChange, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO player_rank_history (
+			player_season_id, player_id, season_id, round_number, old_rank_id, new_rank_id, points
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, change.PlayerSeasonID, change.PlayerID, change.SeasonID, change.RoundNumber,
+		change.OldRankID, change.NewRankID, change.Points,
+	).Scan(&change.ID, &change.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// GetRankHistoryRepo lists rank changes of a season, optionally for a single player
+func (r *rankRepository) GetRankHistoryRepo(ctx context.Context, seasonID, playerID string) ([]models.RankChange, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, player_season_id, player_id, season_id, round_number,
+			old_rank_id, new_rank_id, points, created_at
+		FROM player_rank_history
+		WHERE season_id = $1 AND ($2 = '' OR player_id::text = $2)
+		ORDER BY created_at DESC
+	`, seasonID, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []models.RankChange
+	for rows.Next() {
+		var change models.RankChange
+		err := rows.Scan(
+			&change.ID,
+			&change.PlayerSeasonID,
+			&change.PlayerID,
+			&change.SeasonID,
+			&change.RoundNumber,
+			&change.OldRankID,
+			&change.NewRankID,
+			&change.Points,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
@@ -1,17 +1,91 @@
 package repository
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"backend-ping-pong-app/internal/database"
+)
+
+// uniqueViolation is the Postgres error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// DBTX is implemented by both *sql.DB and *sql.Tx so every repository can run
+// either on the connection pool or inside a transaction
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
 
 type Repository struct {
-	Player PlayerRepository
-	Season SeasonRepository
-	Team   TeamRepository
+	Player       PlayerRepository
+	Season       SeasonRepository
+	Team         TeamRepository
+	Fixture      FixtureRepository
+	Match        MatchRepository
+	Lineup       LineupRepository
+	Round        RoundRepository
+	PlayerSeason PlayerSeasonRepository
+	Rank         RankRepository
+	PointLog     PointLogRepository
+	Settings     SeasonSettingsRepository
+	Leaderboard  LeaderboardRepository
+	Standing     TeamStandingRepository
+	Bracket      BracketRepository
+	Tournament   TournamentRepository
+	Admin        AdminRepository
+	APIKey       APIKeyRepository
+
+	// db is nil when the repository is already bound to a transaction
+	db *sql.DB
 }
 
 func NewRepository(db *sql.DB) *Repository {
+	repo := newRepository(db)
+	repo.db = db
+	return repo
+}
+
+func newRepository(db DBTX) *Repository {
 	return &Repository{
-		Player: NewPlayerRepository(db),
-		Season: NewSeasonRepository(db),
-		Team:   NewTeamRepository(db),
+		Player:       NewPlayerRepository(db),
+		Season:       NewSeasonRepository(db),
+		Team:         NewTeamRepository(db),
+		Fixture:      NewFixtureRepository(db),
+		Match:        NewMatchRepository(db),
+		Lineup:       NewLineupRepository(db),
+		Round:        NewRoundRepository(db),
+		PlayerSeason: NewPlayerSeasonRepository(db),
+		Rank:         NewRankRepository(db),
+		PointLog:     NewPointLogRepository(db),
+		Settings:     NewSeasonSettingsRepository(db),
+		Leaderboard:  NewLeaderboardRepository(db),
+		Standing:     NewTeamStandingRepository(db),
+		Bracket:      NewBracketRepository(db),
+		Tournament:   NewTournamentRepository(db),
+		Admin:        NewAdminRepository(db),
+		APIKey:       NewAPIKeyRepository(db),
+	}
+}
+
+// WithTx runs fn with a Repository whose queries share a single transaction.
+// Calling it on a repository that is already transactional reuses that transaction.
+func (r *Repository) WithTx(ctx context.Context, fn func(tx *Repository) error) error {
+	if r.db == nil {
+		return fn(r)
 	}
+
+	return database.WithTx(ctx, r.db, func(tx *sql.Tx) error {
+		return fn(newRepository(tx))
+	})
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
 }
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type RoundRepository interface {
+	GetRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*models.Round, error)
+	GetLatestFinalizedRoundRepo(ctx context.Context, seasonID string) (int, error)
+	GetOpenRoundsBeforeRepo(ctx context.Context, seasonID string, roundNumber int) ([]int, error)
+	LockRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*models.Round, error)
+	UpdateRoundRepo(ctx context.Context, round *models.Round) (*models.Round, error)
+	GetPendingRoundPointsRepo(ctx context.Context, seasonID string, roundNumber int) ([]models.PlayerRoundPoints, error)
+	CreateRoundPointsRepo(ctx context.Context, points *models.PlayerRoundPoints) error
+	CreateRoundStandingsRepo(ctx context.Context, seasonID string, roundNumber int) ([]models.PlayerRoundStanding, error)
+	AddRoundPointsRepo(ctx context.Context, playerSeasonID string, roundNumber int, delta float64) error
+	ShiftRoundStandingRepo(ctx context.Context, playerSeasonID string, roundNumber int, delta float64) error
+	RerankRoundStandingsRepo(ctx context.Context, seasonID string, roundNumber int) error
+}
+
+type roundRepository struct {
+	db DBTX
+}
+
+func NewRoundRepository(db DBTX) RoundRepository {
+	return &roundRepository{db: db}
+}
+
+func (r *roundRepository) GetRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*models.Round, error) {
+	var round models.Round
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, season_id, round_number, status, finalized_at, created_at, updated_at
+		FROM rounds
+		WHERE season_id = $1 AND round_number = $2
+	`, seasonID, roundNumber).Scan(
+		&round.ID,
+		&round.SeasonID,
+		&round.RoundNumber,
+		&round.Status,
+		&round.FinalizedAt,
+		&round.CreatedAt,
+		&round.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &round, nil
+}
+
+// GetLatestFinalizedRoundRepo returns the highest finalized round number, or 0
+func (r *roundRepository) GetLatestFinalizedRoundRepo(ctx context.Context, seasonID string) (int, error) {
+	var roundNumber int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(round_number), 0)
+		FROM rounds
+		WHERE season_id = $1 AND status = $2
+	`, seasonID, models.RoundStatusFinalized).Scan(&roundNumber)
+
+	return roundNumber, err
+}
+
+// GetOpenRoundsBeforeRepo returns the numbers of the rounds below roundNumber
+// that have fixtures or a round row but are not FINALIZED yet
+func (r *roundRepository) GetOpenRoundsBeforeRepo(ctx context.Context, seasonID string, roundNumber int) ([]int, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT f.round
+		FROM fixtures f
+		WHERE f.season_id = $1 AND f.round < $2
+			AND NOT EXISTS (
+				SELECT 1 FROM rounds r
+				WHERE r.season_id = f.season_id AND r.round_number = f.round AND r.status = $3
+			)
+		UNION
+		SELECT round_number
+		FROM rounds
+		WHERE season_id = $1 AND round_number < $2 AND status <> $3
+		ORDER BY 1
+	`, seasonID, roundNumber, models.RoundStatusFinalized)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rounds []int
+	for rows.Next() {
+		var round int
+		if err := rows.Scan(&round); err != nil {
+			return nil, err
+		}
+		rounds = append(rounds, round)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rounds, nil
+}
+
+// LockRoundRepo creates the round row when missing and locks it for the rest
+// of the transaction
+func (r *roundRepository) LockRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*models.Round, error) {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO rounds (season_id, round_number, status)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (season_id, round_number) DO NOTHING
+	`, seasonID, roundNumber, models.RoundStatusOpen)
+	if err != nil {
+		return nil, err
+	}
+
+	var round models.Round
+	err = r.db.QueryRowContext(ctx, `
+		SELECT id, season_id, round_number, status, finalized_at, created_at, updated_at
+		FROM rounds
+		WHERE season_id = $1 AND round_number = $2
+		FOR UPDATE
+	`, seasonID, roundNumber).Scan(
+		&round.ID,
+		&round.SeasonID,
+		&round.RoundNumber,
+		&round.Status,
+		&round.FinalizedAt,
+		&round.CreatedAt,
+		&round.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &round, nil
+}
+
+func (r *roundRepository) UpdateRoundRepo(ctx context.Context, round *models.Round) (*models.Round, error) {
+	round.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE rounds
+		SET status = $1, finalized_at = $2, updated_at = $3
+		WHERE id = $4
+	`, round.Status, round.FinalizedAt, round.UpdatedAt, round.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return round, nil
+}
+
+// GetPendingRoundPointsRepo sums the MATCH point logs of every player in the
+// season for the rubbers played in the given round
+func (r *roundRepository) GetPendingRoundPointsRepo(ctx context.Context, seasonID string, roundNumber int) ([]models.PlayerRoundPoints, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			ps.id,
+			ps.player_id,
+			ps.accumulated_points,
+			COALESCE(SUM(l.delta_points), 0)
+		FROM player_seasons ps
+		LEFT JOIN player_point_logs l
+			ON l.player_season_id = ps.id
+			AND l.source = 'MATCH'
+			AND l.ref_id IN (
+				SELECT m.id
+				FROM matches m
+				JOIN fixtures f ON f.id = m.fixture_id
+				WHERE f.season_id = $1 AND f.round = $2
+			)
+		WHERE ps.season_id = $1
+		GROUP BY ps.id, ps.player_id, ps.accumulated_points
+		ORDER BY ps.id
+	`, seasonID, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []models.PlayerRoundPoints
+	for rows.Next() {
+		p := models.PlayerRoundPoints{SeasonID: seasonID, RoundNumber: roundNumber}
+		if err := rows.Scan(
+			&p.PlayerSeasonID,
+			&p.PlayerID,
+			&p.PointsBefore,
+			&p.PointsEarned,
+		); err != nil {
+			return nil, err
+		}
+		p.PointsAfter = p.PointsBefore + p.PointsEarned
+		points = append(points, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (r *roundRepository) CreateRoundPointsRepo(ctx context.Context, points *models.PlayerRoundPoints) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO player_round_points (player_season_id, season_id, round_number, points_earned)
+		VALUES ($1, $2, $3, $4)
+	`, points.PlayerSeasonID, points.SeasonID, points.RoundNumber, points.PointsEarned)
+
+	return err
+}
+
+// CreateRoundStandingsRepo snapshots the current ACTIVE leaderboard of the
+// season as the standings after the given round
+func (r *roundRepository) CreateRoundStandingsRepo(ctx context.Context, seasonID string, roundNumber int) ([]models.PlayerRoundStanding, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		INSERT INTO player_round_standings (player_season_id, season_id, round_number, team_id, rank_id, accumulated_points, rank_position)
+		SELECT
+			id,
+			season_id,
+			$2,
+			team_id,
+			rank_id,
+			accumulated_points,
+			RANK() OVER (ORDER BY accumulated_points DESC)
+		FROM player_seasons
+		WHERE season_id = $1 AND status = 'ACTIVE'
+		RETURNING player_season_id, season_id, round_number, team_id, rank_id, accumulated_points, rank_position
+	`, seasonID, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var standings []models.PlayerRoundStanding
+	for rows.Next() {
+		var standing models.PlayerRoundStanding
+		if err := rows.Scan(
+			&standing.PlayerSeasonID,
+			&standing.SeasonID,
+			&standing.RoundNumber,
+			&standing.TeamID,
+			&standing.RankID,
+			&standing.AccumulatedPoints,
+			&standing.RankPosition,
+		); err != nil {
+			return nil, err
+		}
+		standings = append(standings, standing)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// AddRoundPointsRepo corrects the points a player earned in a finalized round
+func (r *roundRepository) AddRoundPointsRepo(ctx context.Context, playerSeasonID string, roundNumber int, delta float64) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_round_points
+		SET points_earned = points_earned + $1
+		WHERE player_season_id = $2 AND round_number = $3
+	`, delta, playerSeasonID, roundNumber)
+
+	return err
+}
+
+// ShiftRoundStandingRepo corrects a player's accumulated points in the
+// standings snapshot of a round; positions are refreshed by RerankRoundStandingsRepo
+func (r *roundRepository) ShiftRoundStandingRepo(ctx context.Context, playerSeasonID string, roundNumber int, delta float64) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_round_standings
+		SET accumulated_points = accumulated_points + $1
+		WHERE player_season_id = $2 AND round_number = $3
+	`, delta, playerSeasonID, roundNumber)
+
+	return err
+}
+
+// RerankRoundStandingsRepo recomputes the positions of a round's standings
+// snapshot from its accumulated points, ranked like CreateRoundStandingsRepo
+func (r *roundRepository) RerankRoundStandingsRepo(ctx context.Context, seasonID string, roundNumber int) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE player_round_standings s
+		SET rank_position = ranked.position
+		FROM (
+			SELECT id, RANK() OVER (ORDER BY accumulated_points DESC) AS position
+			FROM player_round_standings
+			WHERE season_id = $1 AND round_number = $2
+		) ranked
+		WHERE s.id = ranked.id
+	`, seasonID, roundNumber)
+
+	return err
+}
@@ -16,10 +16,10 @@ type SeasonRepository interface {
 }
 
 type seasonRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewSeasonRepository(db *sql.DB) SeasonRepository {
+func NewSeasonRepository(db DBTX) SeasonRepository {
 	return &seasonRepository{db: db}
 }
 
@@ -91,7 +91,7 @@ func (r *seasonRepository) CreateSeason(ctx context.Context, season *models.Seas
 	season.UpdatedAt = now
 
 	if season.Status == "" {
-		season.Status = "UPCOMING"
+		season.Status = models.SeasonStatusUpcoming
 	}
 
 	var id string
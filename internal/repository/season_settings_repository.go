@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type SeasonSettingsRepository interface {
+	GetSeasonSettingsRepo(ctx context.Context, seasonID string) (*models.SeasonSettings, error)
+	UpsertSeasonSettingsRepo(ctx context.Context, settings *models.SeasonSettings) (*models.SeasonSettings, error)
+}
+
+type seasonSettingsRepository struct {
+	db DBTX
+}
+
+func NewSeasonSettingsRepository(db DBTX) SeasonSettingsRepository {
+	return &seasonSettingsRepository{db: db}
+}
+
+func (r *seasonSettingsRepository) GetSeasonSettingsRepo(ctx context.Context, seasonID string) (*models.SeasonSettings, error) {
+	var settings models.SeasonSettings
+	err := r.db.QueryRowContext(ctx, `
+		SELECT season_id, promotion_buffer, relegation_buffer,
+			transfer_window_start_round, transfer_window_end_round, max_transfers_per_player,
+			points_per_win, points_per_draw, points_per_loss,
+			handicap_enabled, handicap_step, handicap_max_points,
+			max_appearances_per_fixture, no_show_penalty_points, updated_at
+		FROM season_settings
+		WHERE season_id = $1
+	`, seasonID).Scan(
+		&settings.SeasonID,
+		&settings.PromotionBuffer,
+		&settings.RelegationBuffer,
+		&settings.TransferWindowStartRound,
+		&settings.TransferWindowEndRound,
+		&settings.MaxTransfersPerPlayer,
+		&settings.PointsPerWin,
+		&settings.PointsPerDraw,
+		&settings.PointsPerLoss,
+		&settings.HandicapEnabled,
+		&settings.HandicapStep,
+		&settings.HandicapMaxPoints,
+		&settings.MaxAppearancesPerFixture,
+		&settings.NoShowPenaltyPoints,
+		&settings.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *seasonSettingsRepository) UpsertSeasonSettingsRepo(ctx context.Context, settings *models.SeasonSettings) (*models.SeasonSettings, error) {
+	settings.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO season_settings (
+			season_id, promotion_buffer, relegation_buffer,
+			transfer_window_start_round, transfer_window_end_round, max_transfers_per_player,
+			points_per_win, points_per_draw, points_per_loss,
+			handicap_enabled, handicap_step, handicap_max_points,
+			max_appearances_per_fixture, no_show_penalty_points, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (season_id) DO UPDATE
+		SET promotion_buffer = EXCLUDED.promotion_buffer,
+			relegation_buffer = EXCLUDED.relegation_buffer,
+			transfer_window_start_round = EXCLUDED.transfer_window_start_round,
+			transfer_window_end_round = EXCLUDED.transfer_window_end_round,
+			max_transfers_per_player = EXCLUDED.max_transfers_per_player,
+			points_per_win = EXCLUDED.points_per_win,
+			points_per_draw = EXCLUDED.points_per_draw,
+			points_per_loss = EXCLUDED.points_per_loss,
+			handicap_enabled = EXCLUDED.handicap_enabled,
+			handicap_step = EXCLUDED.handicap_step,
+			handicap_max_points = EXCLUDED.handicap_max_points,
+			max_appearances_per_fixture = EXCLUDED.max_appearances_per_fixture,
+			no_show_penalty_points = EXCLUDED.no_show_penalty_points,
+			updated_at = EXCLUDED.updated_at
+	`, settings.SeasonID, settings.PromotionBuffer, settings.RelegationBuffer,
+		settings.TransferWindowStartRound, settings.TransferWindowEndRound, settings.MaxTransfersPerPlayer,
+		settings.PointsPerWin, settings.PointsPerDraw, settings.PointsPerLoss,
+		settings.HandicapEnabled, settings.HandicapStep, settings.HandicapMaxPoints,
+		settings.MaxAppearancesPerFixture, settings.NoShowPenaltyPoints, settings.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
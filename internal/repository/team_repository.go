@@ -12,13 +12,18 @@ type TeamRepository interface {
 	GetTeamByIDRepo(ctx context.Context, id string) (*models.Team, error)
 	CreateTeamRepo(ctx context.Context, team *models.Team) (*models.Team, error)
 	GetTeamMembersRepo(ctx context.Context, teamID string) ([]models.TeamMember, error)
+	GetOpenMembershipRepo(ctx context.Context, seasonID, playerID string) (*models.TeamMember, error)
+	GetPlayerTeamAtRoundRepo(ctx context.Context, seasonID, playerID string, round int) (string, error)
+	CountTransfersRepo(ctx context.Context, seasonID, playerID string) (int, error)
+	CreateTeamMemberRepo(ctx context.Context, seasonID string, member *models.TeamMember) error
+	CloseTeamMemberRepo(ctx context.Context, seasonID string, member *models.TeamMember) error
 }
 
 type teamRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewTeamRepository(db *sql.DB) TeamRepository {
+func NewTeamRepository(db DBTX) TeamRepository {
 	return &teamRepository{db: db}
 }
 
@@ -66,6 +71,7 @@ func (r *teamRepository) GetTeamByIDRepo(ctx context.Context, id string) (*model
 		&team.ID,
 		&team.SeasonID,
 		&team.Name,
+		&team.AvatarURL,
 	)
 
 	if err == sql.ErrNoRows {
@@ -128,3 +134,81 @@ func (r *teamRepository) GetTeamMembersRepo(ctx context.Context, teamID string)
 
 	return members, nil
 }
+
+// GetOpenMembershipRepo returns the player's membership that has no left round yet
+func (r *teamRepository) GetOpenMembershipRepo(ctx context.Context, seasonID, playerID string) (*models.TeamMember, error) {
+	var member models.TeamMember
+	err := r.db.QueryRowContext(ctx, `
+		SELECT team_id, player_id, joined_round, left_round, transfer_type
+		FROM team_members
+		WHERE season_id = $1 AND player_id = $2 AND left_round IS NULL
+		ORDER BY joined_round DESC
+		LIMIT 1
+	`, seasonID, playerID).Scan(
+		&member.TeamID,
+		&member.PlayerID,
+		&member.JoinedRound,
+		&member.LeftRound,
+		&member.TransferType,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// GetPlayerTeamAtRoundRepo returns the team the player belonged to in the
+// given round, or "" when no membership covers that round
+func (r *teamRepository) GetPlayerTeamAtRoundRepo(ctx context.Context, seasonID, playerID string, round int) (string, error) {
+	var teamID string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT team_id
+		FROM team_members
+		WHERE season_id = $1 AND player_id = $2
+			AND joined_round <= $3
+			AND (left_round IS NULL OR left_round >= $3)
+		ORDER BY joined_round DESC
+		LIMIT 1
+	`, seasonID, playerID, round).Scan(&teamID)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return teamID, err
+}
+
+func (r *teamRepository) CountTransfersRepo(ctx context.Context, seasonID, playerID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM team_members
+		WHERE season_id = $1 AND player_id = $2 AND transfer_type = $3
+	`, seasonID, playerID, models.TransferTypeTransfer).Scan(&count)
+
+	return count, err
+}
+
+func (r *teamRepository) CreateTeamMemberRepo(ctx context.Context, seasonID string, member *models.TeamMember) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO team_members (season_id, team_id, player_id, joined_round, left_round, transfer_type)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, seasonID, member.TeamID, member.PlayerID, member.JoinedRound, member.LeftRound, member.TransferType)
+
+	return err
+}
+
+// CloseTeamMemberRepo sets the left round of the player's open membership in member.TeamID
+func (r *teamRepository) CloseTeamMemberRepo(ctx context.Context, seasonID string, member *models.TeamMember) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE team_members
+		SET left_round = $1
+		WHERE season_id = $2 AND team_id = $3 AND player_id = $4 AND left_round IS NULL
+	`, member.LeftRound, seasonID, member.TeamID, member.PlayerID)
+
+	return err
+}
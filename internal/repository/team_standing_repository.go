@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type TeamStandingRepository interface {
+	GetTeamStandingsRepo(ctx context.Context, seasonID string) ([]models.TeamStanding, error)
+	GetFixtureResultsRepo(ctx context.Context, seasonID string) ([]models.FixtureResult, error)
+	ReplaceTeamStandingsRepo(ctx context.Context, seasonID string, standings []models.TeamStanding) error
+}
+
+type teamStandingRepository struct {
+	db DBTX
+}
+
+func NewTeamStandingRepository(db DBTX) TeamStandingRepository {
+	return &teamStandingRepository{db: db}
+}
+
+func (r *teamStandingRepository) GetTeamStandingsRepo(ctx context.Context, seasonID string) ([]models.TeamStanding, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ts.season_id, ts.team_id, t.name, ts.position, ts.played,
+			ts.wins, ts.draws, ts.losses, ts.rubbers_won, ts.rubbers_lost,
+			ts.sets_won, ts.sets_lost, ts.points_won, ts.points_lost,
+			ts.total_points, ts.updated_at
+		FROM team_standings ts
+		JOIN teams t ON t.id = ts.team_id
+		WHERE ts.season_id = $1
+		ORDER BY ts.position ASC
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var standings []models.TeamStanding
+	for rows.Next() {
+		var standing models.TeamStanding
+		err := rows.Scan(
+			&standing.SeasonID,
+			&standing.TeamID,
+			&standing.TeamName,
+			&standing.Position,
+			&standing.Played,
+			&standing.Wins,
+			&standing.Draws,
+			&standing.Losses,
+			&standing.RubbersWon,
+			&standing.RubbersLost,
+			&standing.SetsWon,
+			&standing.SetsLost,
+			&standing.PointsWon,
+			&standing.PointsLost,
+			&standing.TotalPoints,
+			&standing.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		standings = append(standings, standing)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// GetFixtureResultsRepo aggregates the rubbers, sets and points of every
+// completed fixture of the season. A set is won by the side with more points.
+// Playoff fixtures belong to a bracket and do not count.
+func (r *teamStandingRepository) GetFixtureResultsRepo(ctx context.Context, seasonID string) ([]models.FixtureResult, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			f.id,
+			f.round,
+			f.home_team_id,
+			f.guest_team_id,
+			f.home_score,
+			f.guest_score,
+			COALESCE(s.home_sets, 0),
+			COALESCE(s.guest_sets, 0),
+			COALESCE(s.home_points, 0),
+			COALESCE(s.guest_points, 0)
+		FROM fixtures f
+		LEFT JOIN LATERAL (
+			SELECT
+				COUNT(*) FILTER (WHERE sets.home > sets.guest AND sets.finished) AS home_sets,
+				COUNT(*) FILTER (WHERE sets.guest > sets.home AND sets.finished) AS guest_sets,
+				SUM(sets.home) AS home_points,
+				SUM(sets.guest) AS guest_points
+			FROM matches m
+			CROSS JOIN LATERAL (
+				-- The last set of a retirement may be unfinished; its points still count
+				SELECT u.home, u.guest, GREATEST(u.home, u.guest) >= 11 AND ABS(u.home - u.guest) >= 2 AS finished
+				FROM unnest(m.home_sets, m.guest_sets) AS u(home, guest)
+			) sets
+			WHERE m.fixture_id = f.id
+		) s ON true
+		WHERE f.season_id = $1 AND f.status = $2
+			AND NOT EXISTS (SELECT 1 FROM bracket_matches bm WHERE bm.fixture_id = f.id)
+		ORDER BY f.round ASC
+	`, seasonID, models.FixtureStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.FixtureResult
+	for rows.Next() {
+		var result models.FixtureResult
+		err := rows.Scan(
+			&result.FixtureID,
+			&result.Round,
+			&result.HomeTeamID,
+			&result.GuestTeamID,
+			&result.HomeRubbers,
+			&result.GuestRubbers,
+			&result.HomeSets,
+			&result.GuestSets,
+			&result.HomePoints,
+			&result.GuestPoints,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ReplaceTeamStandingsRepo overwrites the stored standings of the season
+func (r *teamStandingRepository) ReplaceTeamStandingsRepo(ctx context.Context, seasonID string, standings []models.TeamStanding) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM team_standings WHERE season_id = $1`, seasonID); err != nil {
+		return err
+	}
+
+	for _, standing := range standings {
+		_, err := r.db.ExecContext(ctx, `
+			INSERT INTO team_standings (
+				season_id, team_id, position, played, wins, draws, losses,
+				rubbers_won, rubbers_lost, sets_won, sets_lost, points_won, points_lost,
+				total_points, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`, seasonID, standing.TeamID, standing.Position, standing.Played,
+			standing.Wins, standing.Draws, standing.Losses,
+			standing.RubbersWon, standing.RubbersLost, standing.SetsWon, standing.SetsLost,
+			standing.PointsWon, standing.PointsLost, standing.TotalPoints, standing.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"backend-ping-pong-app/internal/models"
+)
+
+type TournamentRepository interface {
+	GetTournamentsBySeasonRepo(ctx context.Context, seasonID string) ([]models.Tournament, error)
+	GetTournamentByIDRepo(ctx context.Context, id string) (*models.Tournament, error)
+	LockTournamentRepo(ctx context.Context, id string) (*models.Tournament, error)
+	CreateTournamentRepo(ctx context.Context, tournament *models.Tournament) (*models.Tournament, error)
+	UpdateTournamentStatusRepo(ctx context.Context, id, status string) error
+	GetEntriesRepo(ctx context.Context, tournamentID string) ([]models.TournamentEntry, error)
+	CreateEntryRepo(ctx context.Context, entry *models.TournamentEntry) (*models.TournamentEntry, error)
+	UpdateEntryDrawRepo(ctx context.Context, id string, seed, groupNumber int) error
+	GetTournamentMatchesRepo(ctx context.Context, tournamentID string) ([]models.TournamentMatch, error)
+	LockTournamentMatchRepo(ctx context.Context, id string) (*models.TournamentMatch, error)
+	LockKnockoutMatchRepo(ctx context.Context, tournamentID string, round, position int) (*models.TournamentMatch, error)
+	CreateTournamentMatchRepo(ctx context.Context, match *models.TournamentMatch) (*models.TournamentMatch, error)
+	UpdateTournamentMatchRepo(ctx context.Context, match *models.TournamentMatch) error
+}
+
+type tournamentRepository struct {
+	db DBTX
+}
+
+func NewTournamentRepository(db DBTX) TournamentRepository {
+	return &tournamentRepository{db: db}
+}
+
+const tournamentColumns = `id, season_id, name, group_count, qualifiers_per_group, status, created_at, updated_at`
+
+func scanTournament(row rowScanner, tournament *models.Tournament) error {
+	return row.Scan(
+		&tournament.ID,
+		&tournament.SeasonID,
+		&tournament.Name,
+		&tournament.GroupCount,
+		&tournament.QualifiersPerGroup,
+		&tournament.Status,
+		&tournament.CreatedAt,
+		&tournament.UpdatedAt,
+	)
+}
+
+func (r *tournamentRepository) GetTournamentsBySeasonRepo(ctx context.Context, seasonID string) ([]models.Tournament, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+tournamentColumns+`
+		FROM tournaments
+		WHERE season_id = $1
+		ORDER BY created_at DESC
+	`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tournaments []models.Tournament
+	for rows.Next() {
+		var tournament models.Tournament
+		if err := scanTournament(rows, &tournament); err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tournaments, nil
+}
+
+func (r *tournamentRepository) GetTournamentByIDRepo(ctx context.Context, id string) (*models.Tournament, error) {
+	return r.getTournament(ctx, id, "")
+}
+
+// LockTournamentRepo reads a tournament with a row lock; it must run inside a transaction
+func (r *tournamentRepository) LockTournamentRepo(ctx context.Context, id string) (*models.Tournament, error) {
+	return r.getTournament(ctx, id, "FOR UPDATE")
+}
+
+func (r *tournamentRepository) getTournament(ctx context.Context, id string, lockClause string) (*models.Tournament, error) {
+	var tournament models.Tournament
+	err := scanTournament(r.db.QueryRowContext(ctx, `
+		SELECT `+tournamentColumns+`
+		FROM tournaments
+		WHERE id = $1
+		`+lockClause, id), &tournament)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tournament, nil
+}
+
+func (r *tournamentRepository) CreateTournamentRepo(ctx context.Context, tournament *models.Tournament) (*models.Tournament, error) {
+	now := time.Now()
+	tournament.CreatedAt = now
+	tournament.UpdatedAt = now
+	tournament.Status = models.TournamentStatusRegistration
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO tournaments (season_id, name, group_count, qualifiers_per_group, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, tournament.SeasonID, tournament.Name, tournament.GroupCount, tournament.QualifiersPerGroup,
+		tournament.Status, tournament.CreatedAt, tournament.UpdatedAt,
+	).Scan(&tournament.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tournament, nil
+}
+
+func (r *tournamentRepository) UpdateTournamentStatusRepo(ctx context.Context, id, status string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE tournaments
+		SET status = $1, updated_at = now()
+		WHERE id = $2
+	`, status, id)
+
+	return err
+}
+
+func (r *tournamentRepository) GetEntriesRepo(ctx context.Context, tournamentID string) ([]models.TournamentEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT te.id, te.tournament_id, te.player_id, p.full_name, te.player_season_id,
+			te.team_id, te.seed, te.group_number, te.created_at
+		FROM tournament_entries te
+		JOIN players p ON p.id = te.player_id
+		WHERE te.tournament_id = $1
+		ORDER BY te.seed ASC NULLS LAST, te.created_at ASC
+	`, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.TournamentEntry
+	for rows.Next() {
+		var entry models.TournamentEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TournamentID,
+			&entry.PlayerID,
+			&entry.PlayerName,
+			&entry.PlayerSeasonID,
+			&entry.TeamID,
+			&entry.Seed,
+			&entry.GroupNumber,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *tournamentRepository) CreateEntryRepo(ctx context.Context, entry *models.TournamentEntry) (*models.TournamentEntry, error) {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO tournament_entries (tournament_id, player_id, player_season_id, team_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, entry.TournamentID, entry.PlayerID, entry.PlayerSeasonID, entry.TeamID).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *tournamentRepository) UpdateEntryDrawRepo(ctx context.Context, id string, seed, groupNumber int) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_entries
+		SET seed = $1, group_number = $2
+		WHERE id = $3
+	`, seed, groupNumber, id)
+
+	return err
+}
+
+const tournamentMatchColumns = `id, tournament_id, stage, group_number, round, position,
+	home_entry_id, guest_entry_id, home_sets, guest_sets, winner_entry_id,
+	point_before, point_after, created_at, updated_at`
+
+func scanTournamentMatch(row rowScanner, match *models.TournamentMatch) error {
+	return row.Scan(
+		&match.ID,
+		&match.TournamentID,
+		&match.Stage,
+		&match.GroupNumber,
+		&match.Round,
+		&match.Position,
+		&match.HomeEntryID,
+		&match.GuestEntryID,
+		pq.Array(&match.HomeSets),
+		pq.Array(&match.GuestSets),
+		&match.WinnerEntryID,
+		&match.PointBefore,
+		&match.PointAfter,
+		&match.CreatedAt,
+		&match.UpdatedAt,
+	)
+}
+
+func (r *tournamentRepository) GetTournamentMatchesRepo(ctx context.Context, tournamentID string) ([]models.TournamentMatch, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+tournamentMatchColumns+`
+		FROM tournament_matches
+		WHERE tournament_id = $1
+		ORDER BY stage DESC, group_number ASC, round ASC, position ASC
+	`, tournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []models.TournamentMatch
+	for rows.Next() {
+		var match models.TournamentMatch
+		if err := scanTournamentMatch(rows, &match); err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// LockTournamentMatchRepo reads a match with a row lock; it must run inside a transaction
+func (r *tournamentRepository) LockTournamentMatchRepo(ctx context.Context, id string) (*models.TournamentMatch, error) {
+	var match models.TournamentMatch
+	err := scanTournamentMatch(r.db.QueryRowContext(ctx, `
+		SELECT `+tournamentMatchColumns+`
+		FROM tournament_matches
+		WHERE id = $1
+		FOR UPDATE
+	`, id), &match)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+// LockKnockoutMatchRepo reads a knockout node with a row lock; it must run inside a transaction
+func (r *tournamentRepository) LockKnockoutMatchRepo(ctx context.Context, tournamentID string, round, position int) (*models.TournamentMatch, error) {
+	var match models.TournamentMatch
+	err := scanTournamentMatch(r.db.QueryRowContext(ctx, `
+		SELECT `+tournamentMatchColumns+`
+		FROM tournament_matches
+		WHERE tournament_id = $1 AND stage = $2 AND round = $3 AND position = $4
+		FOR UPDATE
+	`, tournamentID, models.TournamentStageKnockout, round, position), &match)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &match, nil
+}
+
+func (r *tournamentRepository) CreateTournamentMatchRepo(ctx context.Context, match *models.TournamentMatch) (*models.TournamentMatch, error) {
+	now := time.Now()
+	match.CreatedAt = now
+	match.UpdatedAt = now
+
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO tournament_matches (
+			tournament_id, stage, group_number, round, position,
+			home_entry_id, guest_entry_id, winner_entry_id, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id
+	`, match.TournamentID, match.Stage, match.GroupNumber, match.Round, match.Position,
+		match.HomeEntryID, match.GuestEntryID, match.WinnerEntryID, match.CreatedAt, match.UpdatedAt,
+	).Scan(&match.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+func (r *tournamentRepository) UpdateTournamentMatchRepo(ctx context.Context, match *models.TournamentMatch) error {
+	match.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE tournament_matches
+		SET home_entry_id = $1, guest_entry_id = $2, home_sets = $3, guest_sets = $4,
+			winner_entry_id = $5, point_before = $6, point_after = $7, updated_at = $8
+		WHERE id = $9
+	`, match.HomeEntryID, match.GuestEntryID, pq.Array(match.HomeSets), pq.Array(match.GuestSets),
+		match.WinnerEntryID, match.PointBefore, match.PointAfter, match.UpdatedAt, match.ID)
+
+	return err
+}
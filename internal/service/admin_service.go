@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"backend-ping-pong-app/internal/auth"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type AdminService interface {
+	GetAdminsService(ctx context.Context) ([]models.Admin, error)
+	GetAdminByEmailService(ctx context.Context, email string) (*models.Admin, error)
+	InviteAdminService(ctx context.Context, email, role, password string) (*models.AdminCredentials, error)
+	ResetAdminPasswordService(ctx context.Context, adminID, password string) (*models.AdminCredentials, error)
+	ChangeAdminRoleService(ctx context.Context, adminID, role string) (*models.Admin, error)
+	SetAdminActiveService(ctx context.Context, adminID string, active bool) (*models.Admin, error)
+}
+
+type adminService struct {
+	store *repository.Repository
+}
+
+func NewAdminService(store *repository.Repository) AdminService {
+	return &adminService{store: store}
+}
+
+func (s *adminService) GetAdminsService(ctx context.Context) ([]models.Admin, error) {
+	admins, err := s.store.Admin.GetAdminsRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if admins == nil {
+		admins = []models.Admin{}
+	}
+	return admins, nil
+}
+
+func (s *adminService) GetAdminByEmailService(ctx context.Context, email string) (*models.Admin, error) {
+	admin, err := s.store.Admin.GetAdminByEmailRepo(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, apperrors.AdminNotFound()
+	}
+	return admin, nil
+}
+
+// InviteAdminService creates a staff account. Without a password a temporary
+// one is generated; either way it must be changed at the first sign-in.
+func (s *adminService) InviteAdminService(ctx context.Context, email, role, password string) (*models.AdminCredentials, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !strings.Contains(email, "@") {
+		return nil, apperrors.InvalidInput("Email không hợp lệ")
+	}
+	if !models.IsAdminRole(role) {
+		return nil, invalidAdminRole(role)
+	}
+
+	password, hash, err := temporaryPassword(password, email)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.Admin.GetAdminByEmailRepo(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.AdminAlreadyExists()
+	}
+
+	admin := &models.Admin{
+		Email:              email,
+		PasswordHash:       hash,
+		Role:               role,
+		IsActive:           true,
+		MustChangePassword: true,
+	}
+	if err := s.store.Admin.CreateAdminRepo(ctx, admin); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, apperrors.AdminAlreadyExists()
+		}
+		return nil, err
+	}
+
+	return &models.AdminCredentials{Admin: *admin, TemporaryPassword: password}, nil
+}
+
+// ResetAdminPasswordService sets a new temporary password, generated when
+// empty, signing the admin out everywhere until they choose their own
+func (s *adminService) ResetAdminPasswordService(ctx context.Context, adminID, password string) (*models.AdminCredentials, error) {
+	admin, err := s.store.Admin.GetAdminByIDRepo(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, apperrors.AdminNotFound()
+	}
+
+	password, hash, err := temporaryPassword(password, admin.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.Admin.UpdateAdminPasswordRepo(ctx, admin.ID, hash, true); err != nil {
+		return nil, err
+	}
+
+	admin, err = s.store.Admin.GetAdminByIDRepo(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AdminCredentials{Admin: *admin, TemporaryPassword: password}, nil
+}
+
+// ChangeAdminRoleService changes the role of an admin, keeping at least one
+// active ADMIN. The change applies to their next request.
+func (s *adminService) ChangeAdminRoleService(ctx context.Context, adminID, role string) (*models.Admin, error) {
+	if !models.IsAdminRole(role) {
+		return nil, invalidAdminRole(role)
+	}
+
+	var updated *models.Admin
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		admin, err := tx.Admin.LockAdminRepo(ctx, adminID)
+		if err != nil {
+			return err
+		}
+		if admin == nil {
+			return apperrors.AdminNotFound()
+		}
+
+		if admin.Role == models.AdminRoleAdmin && role != models.AdminRoleAdmin && admin.IsActive {
+			if err := ensureOtherActiveAdmin(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Admin.UpdateAdminRoleRepo(ctx, admin.ID, role); err != nil {
+			return err
+		}
+		updated, err = tx.Admin.GetAdminByIDRepo(ctx, admin.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// SetAdminActiveService deactivates or reactivates an admin. A deactivated
+// admin is refused from their next request on. Admins cannot deactivate
+// themselves, nor the last active ADMIN.
+func (s *adminService) SetAdminActiveService(ctx context.Context, adminID string, active bool) (*models.Admin, error) {
+	if identity, ok := auth.IdentityFromContext(ctx); ok && identity.AdminID == adminID && !active {
+		return nil, apperrors.InvalidInput("Không thể tự vô hiệu hóa tài khoản của mình")
+	}
+
+	var updated *models.Admin
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		admin, err := tx.Admin.LockAdminRepo(ctx, adminID)
+		if err != nil {
+			return err
+		}
+		if admin == nil {
+			return apperrors.AdminNotFound()
+		}
+
+		if !active && admin.IsActive && admin.Role == models.AdminRoleAdmin {
+			if err := ensureOtherActiveAdmin(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Admin.SetAdminActiveRepo(ctx, admin.ID, active); err != nil {
+			return err
+		}
+		updated, err = tx.Admin.GetAdminByIDRepo(ctx, admin.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ensureOtherActiveAdmin refuses to demote or deactivate the only active ADMIN
+func ensureOtherActiveAdmin(ctx context.Context, tx *repository.Repository) error {
+	count, err := tx.Admin.CountActiveAdminsRepo(ctx, models.AdminRoleAdmin)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return apperrors.LastAdmin()
+	}
+	return nil
+}
+
+// temporaryPassword returns password, or a generated one when empty, with
+// its hash
+func temporaryPassword(password, email string) (string, string, error) {
+	if password == "" {
+		generated, err := auth.GenerateTemporaryPassword()
+		if err != nil {
+			return "", "", err
+		}
+		password = generated
+	}
+
+	hash, err := hashValidPassword(password, email)
+	if err != nil {
+		return "", "", err
+	}
+	return password, hash, nil
+}
+
+func invalidAdminRole(role string) error {
+	return apperrors.InvalidInput("Vai trò không hợp lệ").WithDetails(map[string]interface{}{
+		"role":    role,
+		"allowed": []string{models.AdminRoleAdmin, models.AdminRoleModerator, models.AdminRoleCaptain, models.AdminRoleViewer},
+	})
+}
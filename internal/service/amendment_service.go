@@ -0,0 +1,592 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"backend-ping-pong-app/internal/config"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/rating"
+	"backend-ping-pong-app/internal/repository"
+)
+
+// pointEpsilon is half the 0.01 resolution points are stored with; smaller
+// differences are rounding noise
+const pointEpsilon = 0.005
+
+type AmendmentService interface {
+	AmendMatchService(ctx context.Context, seasonID, fixtureID, matchID, reason string, result models.MatchResult) (*models.MatchAmendmentResult, error)
+	GetMatchAmendmentsService(ctx context.Context, seasonID, fixtureID, matchID string) ([]models.MatchAmendment, error)
+}
+
+// amendmentService corrects recorded rubbers. It works on the whole
+// repository because an amendment rewrites point logs, ratings and round
+// snapshots in a single transaction.
+type amendmentService struct {
+	store  *repository.Repository
+	league config.LeagueConfig
+	rating rating.Calculator
+}
+
+func NewAmendmentService(store *repository.Repository, league config.LeagueConfig, calculator rating.Calculator) AmendmentService {
+	return &amendmentService{store: store, league: league, rating: calculator}
+}
+
+// AmendMatchService replaces the result of a recorded rubber. The original
+// MATCH point logs are reversed with compensating rows, every later rubber of
+// the affected players is re-rated, and the points and standings snapshots of
+// finalized rounds are corrected. A rubber that is no longer a walkover also
+// gets its no-show penalties refunded.
+func (s *amendmentService) AmendMatchService(ctx context.Context, seasonID, fixtureID, matchID, reason string, result models.MatchResult) (*models.MatchAmendmentResult, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, apperrors.InvalidInput("reason là bắt buộc khi sửa kết quả")
+	}
+
+	if result.ResultType == "" {
+		result.ResultType = models.ResultTypeNormal
+	}
+	if !models.IsValidResultType(result.ResultType) {
+		return nil, apperrors.InvalidInput("result_type phải là NORMAL, WALKOVER, RETIRED hoặc DISQUALIFIED")
+	}
+
+	amended := &models.Match{
+		HomeSets:     result.HomeSets,
+		GuestSets:    result.GuestSets,
+		WinnerTeamID: result.WinnerTeamID,
+		ResultType:   result.ResultType,
+	}
+	homeWins, guestWins, err := validateResultSets(amended, s.league.BestOf)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *models.MatchAmendmentResult
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		fixture, err := tx.Fixture.LockFixtureRepo(ctx, fixtureID)
+		if err != nil {
+			return err
+		}
+		if fixture == nil || fixture.SeasonID != seasonID {
+			return apperrors.FixtureNotFound()
+		}
+		if fixture.Status == models.FixtureStatusCancelled {
+			return apperrors.FixtureNotActive()
+		}
+
+		match, err := tx.Match.GetMatchByIDRepo(ctx, matchID)
+		if err != nil {
+			return err
+		}
+		if match == nil || match.FixtureID != fixtureID {
+			return apperrors.MatchNotFound()
+		}
+		if !match.Played() {
+			return apperrors.MatchNotRecorded()
+		}
+
+		homeWon, err := resultWinner(fixture, amended, homeWins, guestWins)
+		if err != nil {
+			return err
+		}
+		if homeWon {
+			amended.WinnerTeamID = &fixture.HomeTeamID
+		} else {
+			amended.WinnerTeamID = &fixture.GuestTeamID
+		}
+
+		out = &models.MatchAmendmentResult{
+			FixtureBefore:     *fixture,
+			Ratings:           []models.AmendedRating{},
+			Points:            []models.AmendedPoints{},
+			RegeneratedRounds: []int{},
+			RankChanges:       []models.RankChange{},
+		}
+		before := match.Result()
+
+		match.HomeSets = amended.HomeSets
+		match.GuestSets = amended.GuestSets
+		match.WinnerTeamID = amended.WinnerTeamID
+		match.ResultType = amended.ResultType
+
+		replay, err := newRatingReplay(ctx, tx, seasonID, s.rating, out)
+		if err != nil {
+			return err
+		}
+
+		refunds := make(map[string]float64)
+		if match.ResultType != models.ResultTypeWalkover {
+			if refunds, err = replay.refundPenalties(ctx, match, reason); err != nil {
+				return err
+			}
+		}
+
+		if err := replay.run(ctx, match, fixture.Round, refunds, reason); err != nil {
+			return err
+		}
+		if err := replay.apply(ctx); err != nil {
+			return err
+		}
+		out.Match = match
+
+		amendment, err := tx.Match.CreateMatchAmendmentRepo(ctx, &models.MatchAmendment{
+			MatchID: match.ID,
+			Reason:  reason,
+			Before:  before,
+			After:   match.Result(),
+		})
+		if err != nil {
+			return err
+		}
+		out.Amendment = *amendment
+
+		if out.FixtureAfter, err = s.syncAmendedFixture(ctx, tx, fixture); err != nil {
+			return err
+		}
+
+		if len(out.Points) == 0 {
+			return nil
+		}
+		roundNumber, err := tx.Round.GetLatestFinalizedRoundRepo(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		changes, err := recalculateRanks(ctx, tx, seasonID, roundNumber)
+		if err != nil {
+			return err
+		}
+		out.RankChanges = changes
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// syncAmendedFixture recomputes the score of the amended fixture. A fixture
+// of a finalized round must stay completed, and a decided knockout fixture
+// must keep its winner because the bracket has already moved on.
+func (s *amendmentService) syncAmendedFixture(ctx context.Context, tx *repository.Repository, fixture *models.Fixture) (*models.Fixture, error) {
+	updated, err := syncFixtureScore(ctx, tx, fixture, s.league)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated.Status != models.FixtureStatusCompleted {
+		round, err := tx.Round.GetRoundRepo(ctx, updated.SeasonID, updated.Round)
+		if err != nil {
+			return nil, err
+		}
+		if round != nil && round.Status == models.RoundStatusFinalized {
+			return nil, apperrors.InvalidInput("Kết quả sửa khiến trận đấu của vòng đã chốt chưa kết thúc")
+		}
+	}
+
+	node, err := tx.Bracket.GetBracketMatchByFixtureRepo(ctx, updated.ID)
+	if err != nil {
+		return nil, err
+	}
+	if node != nil && node.WinnerTeamID != nil {
+		winnerID := ""
+		switch {
+		case updated.HomeScore > updated.GuestScore:
+			winnerID = updated.HomeTeamID
+		case updated.GuestScore > updated.HomeScore:
+			winnerID = updated.GuestTeamID
+		}
+		if updated.Status != models.FixtureStatusCompleted || winnerID != *node.WinnerTeamID {
+			return nil, apperrors.InvalidInput("Không thể đổi đội thắng của trận loại trực tiếp đã phân định")
+		}
+	}
+
+	return updated, nil
+}
+
+func (s *amendmentService) GetMatchAmendmentsService(ctx context.Context, seasonID, fixtureID, matchID string) ([]models.MatchAmendment, error) {
+	fixture, err := s.store.Fixture.GetFixtureByIDRepo(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	if fixture == nil || fixture.SeasonID != seasonID {
+		return nil, apperrors.FixtureNotFound()
+	}
+
+	match, err := s.store.Match.GetMatchByIDRepo(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match == nil || match.FixtureID != fixtureID {
+		return nil, apperrors.MatchNotFound()
+	}
+
+	amendments, err := s.store.Match.GetMatchAmendmentsRepo(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if amendments == nil {
+		amendments = []models.MatchAmendment{}
+	}
+	return amendments, nil
+}
+
+// ratingReplay re-rates the rubbers following an amended one. Each rubber is
+// rated again from its point_before snapshot moved by the correction its
+// players have received so far. Rating changes only reach accumulated points
+// when a round is finalized, so the corrections of a round carry into later
+// rounds only once it is finalized; the ranks played at are kept from the
+// rank snapshots.
+type ratingReplay struct {
+	tx              *repository.Repository
+	seasonID        string
+	rating          rating.Calculator
+	kFactors        map[string]float64
+	playerSeasonIDs map[string]string  // player id -> player season id
+	shift           map[string]float64 // player id -> correction of accumulated points so far
+	result          *models.MatchAmendmentResult
+}
+
+func newRatingReplay(ctx context.Context, tx *repository.Repository, seasonID string, calculator rating.Calculator, result *models.MatchAmendmentResult) (*ratingReplay, error) {
+	ranks, err := tx.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kFactors := make(map[string]float64, len(ranks))
+	for _, rank := range ranks {
+		kFactors[rank.ID] = float64(rank.KFactor)
+	}
+
+	return &ratingReplay{
+		tx:              tx,
+		seasonID:        seasonID,
+		rating:          calculator,
+		kFactors:        kFactors,
+		playerSeasonIDs: make(map[string]string),
+		shift:           make(map[string]float64),
+		result:          result,
+	}, nil
+}
+
+func (r *ratingReplay) playerSeasonID(ctx context.Context, playerID string) (string, error) {
+	if id, ok := r.playerSeasonIDs[playerID]; ok {
+		return id, nil
+	}
+
+	ps, err := r.tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, r.seasonID, playerID)
+	if err != nil {
+		return "", err
+	}
+	if ps == nil {
+		return "", apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"player_id": playerID})
+	}
+
+	r.playerSeasonIDs[playerID] = ps.ID
+	return ps.ID, nil
+}
+
+// refundPenalties reverses the no-show penalties logged against the rubber and
+// returns the refund of each player
+func (r *ratingReplay) refundPenalties(ctx context.Context, match *models.Match, reason string) (map[string]float64, error) {
+	logs, err := r.tx.PointLog.GetPointLogsByRefRepo(ctx, match.ID)
+	if err != nil {
+		return nil, err
+	}
+	penalties := make(map[string]float64)
+	for _, log := range logs {
+		if log.Source == models.PointSourcePenalty {
+			penalties[log.PlayerSeasonID] += log.DeltaPoints
+		}
+	}
+
+	refunds := make(map[string]float64)
+	refundReason := fmt.Sprintf("Hoàn phạt bỏ trận %d: %s", match.MatchOrder, reason)
+	for _, playerID := range append(match.HomePlayerIDs(), match.GuestPlayerIDs()...) {
+		psID, err := r.playerSeasonID(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		penalty := penalties[psID]
+		if math.Abs(penalty) < pointEpsilon {
+			continue
+		}
+
+		_, err = r.tx.PointLog.CreatePointLogRepo(ctx, &models.PointLog{
+			PlayerSeasonID: psID,
+			DeltaPoints:    -penalty,
+			Reason:         &refundReason,
+			Source:         models.PointSourcePenalty,
+			RefID:          &match.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		refunds[playerID] = -penalty
+	}
+
+	return refunds, nil
+}
+
+// run replays every rubber from the amended one's round on. The amended rubber
+// is always re-rated; a later rubber only when one of its players has a
+// correction. refunds are point corrections applied with the amended round.
+func (r *ratingReplay) run(ctx context.Context, amended *models.Match, fromRound int, refunds map[string]float64, reason string) error {
+	fixtures, err := r.tx.Fixture.GetFixturesBySeasonRepo(ctx, r.seasonID, models.FixtureFilter{})
+	if err != nil {
+		return err
+	}
+
+	var rounds []int
+	byRound := make(map[int][]models.Fixture)
+	for _, fixture := range fixtures {
+		if fixture.Round < fromRound {
+			continue
+		}
+		if _, ok := byRound[fixture.Round]; !ok {
+			rounds = append(rounds, fixture.Round)
+		}
+		byRound[fixture.Round] = append(byRound[fixture.Round], fixture)
+	}
+
+	for _, roundNumber := range rounds {
+		roundDiff := make(map[string]float64)
+		for i := range byRound[roundNumber] {
+			fixture := &byRound[roundNumber][i]
+			matches, err := r.tx.Match.GetMatchesByFixtureRepo(ctx, fixture.ID)
+			if err != nil {
+				return err
+			}
+
+			for j := range matches {
+				match := &matches[j]
+				if match.ID == amended.ID {
+					match = amended
+				} else if !match.Played() || !r.shifted(match) {
+					continue
+				}
+
+				diff, err := r.rerate(ctx, fixture, match, reason)
+				if err != nil {
+					return err
+				}
+				for playerID, delta := range diff {
+					roundDiff[playerID] += delta
+				}
+			}
+		}
+
+		if roundNumber == fromRound {
+			for playerID, refund := range refunds {
+				r.shift[playerID] += refund
+			}
+		}
+
+		round, err := r.tx.Round.GetRoundRepo(ctx, r.seasonID, roundNumber)
+		if err != nil {
+			return err
+		}
+		if round == nil || round.Status != models.RoundStatusFinalized {
+			continue
+		}
+
+		for playerID, delta := range roundDiff {
+			if err := r.tx.Round.AddRoundPointsRepo(ctx, r.playerSeasonIDs[playerID], roundNumber, delta); err != nil {
+				return err
+			}
+			r.shift[playerID] += delta
+		}
+
+		if err := r.regenerateStandings(ctx, roundNumber); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// shifted reports whether a player of the rubber has a correction so far
+func (r *ratingReplay) shifted(match *models.Match) bool {
+	for _, playerID := range append(match.HomePlayerIDs(), match.GuestPlayerIDs()...) {
+		if math.Abs(r.shift[playerID]) >= pointEpsilon {
+			return true
+		}
+	}
+	return false
+}
+
+// rerate rates a rubber again, replaces its MATCH point logs where the delta
+// changed and returns the change of each player's delta
+func (r *ratingReplay) rerate(ctx context.Context, fixture *models.Fixture, match *models.Match, reason string) (map[string]float64, error) {
+	pointBefore := make(map[string]float64)
+	if match.PointBefore != nil {
+		if err := json.Unmarshal([]byte(*match.PointBefore), &pointBefore); err != nil {
+			return nil, err
+		}
+	}
+	rankSnapshot := make(map[string]string)
+	if match.RankSnapshot != nil {
+		if err := json.Unmarshal([]byte(*match.RankSnapshot), &rankSnapshot); err != nil {
+			return nil, err
+		}
+	}
+
+	participants := func(playerIDs []string) []rating.Participant {
+		side := make([]rating.Participant, 0, len(playerIDs))
+		for _, playerID := range playerIDs {
+			side = append(side, rating.Participant{
+				PlayerID: playerID,
+				Rating:   pointBefore[playerID] + r.shift[playerID],
+				KFactor:  r.kFactors[rankSnapshot[playerID]],
+			})
+		}
+		return side
+	}
+
+	deltas := make(map[string]float64)
+	if match.Rated() {
+		deltas = r.rating.Deltas(rating.Outcome{
+			Home:    participants(match.HomePlayerIDs()),
+			Guest:   participants(match.GuestPlayerIDs()),
+			HomeWon: *match.WinnerTeamID == fixture.HomeTeamID,
+		})
+	}
+
+	logs, err := r.tx.PointLog.GetPointLogsByRefRepo(ctx, match.ID)
+	if err != nil {
+		return nil, err
+	}
+	logged := make(map[string]float64)
+	for _, log := range logs {
+		if log.Source == models.PointSourceMatch {
+			logged[log.PlayerSeasonID] += log.DeltaPoints
+		}
+	}
+
+	diff := make(map[string]float64)
+	pointAfter := make(map[string]float64)
+	for _, playerID := range append(match.HomePlayerIDs(), match.GuestPlayerIDs()...) {
+		psID, err := r.playerSeasonID(ctx, playerID)
+		if err != nil {
+			return nil, err
+		}
+
+		pointBefore[playerID] += r.shift[playerID]
+		pointAfter[playerID] = pointBefore[playerID] + deltas[playerID]
+
+		old, updated := logged[psID], deltas[playerID]
+		if math.Abs(updated-old) < pointEpsilon {
+			continue
+		}
+		if err := r.relog(ctx, psID, match, old, updated, reason); err != nil {
+			return nil, err
+		}
+
+		diff[playerID] = updated - old
+		r.result.Ratings = append(r.result.Ratings, models.AmendedRating{
+			MatchID:     match.ID,
+			RoundNumber: fixture.Round,
+			PlayerID:    playerID,
+			DeltaBefore: old,
+			DeltaAfter:  updated,
+		})
+	}
+
+	if match.PointBefore, err = jsonString(pointBefore); err != nil {
+		return nil, err
+	}
+	if match.PointAfter, err = jsonString(pointAfter); err != nil {
+		return nil, err
+	}
+	if _, err := r.tx.Match.UpdateMatchResultRepo(ctx, match); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// relog reverses the MATCH delta logged for a player in a rubber and logs the
+// replayed one
+func (r *ratingReplay) relog(ctx context.Context, playerSeasonID string, match *models.Match, old, updated float64, reason string) error {
+	logs := []struct {
+		delta  float64
+		reason string
+	}{
+		{-old, fmt.Sprintf("Hoàn tác kết quả trận %d: %s", match.MatchOrder, reason)},
+		{updated, fmt.Sprintf("Kết quả trận %d sau khi sửa: %s", match.MatchOrder, reason)},
+	}
+
+	for _, l := range logs {
+		if l.delta == 0 {
+			continue
+		}
+		logReason := l.reason
+		_, err := r.tx.PointLog.CreatePointLogRepo(ctx, &models.PointLog{
+			PlayerSeasonID: playerSeasonID,
+			DeltaPoints:    l.delta,
+			Reason:         &logReason,
+			Source:         models.PointSourceMatch,
+			RefID:          &match.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// regenerateStandings moves the standings snapshot of a finalized round by the
+// corrections made up to that round and ranks it again
+func (r *ratingReplay) regenerateStandings(ctx context.Context, roundNumber int) error {
+	changed := false
+	for playerID, delta := range r.shift {
+		if math.Abs(delta) < pointEpsilon {
+			continue
+		}
+		if err := r.tx.Round.ShiftRoundStandingRepo(ctx, r.playerSeasonIDs[playerID], roundNumber, delta); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := r.tx.Round.RerankRoundStandingsRepo(ctx, r.seasonID, roundNumber); err != nil {
+		return err
+	}
+	r.result.RegeneratedRounds = append(r.result.RegeneratedRounds, roundNumber)
+	return nil
+}
+
+// apply adds the total correction of every player to their accumulated points
+func (r *ratingReplay) apply(ctx context.Context) error {
+	playerIDs := make([]string, 0, len(r.shift))
+	for playerID, delta := range r.shift {
+		if math.Abs(delta) >= pointEpsilon {
+			playerIDs = append(playerIDs, playerID)
+		}
+	}
+	sort.Strings(playerIDs)
+
+	for _, playerID := range playerIDs {
+		delta := r.shift[playerID]
+		total, err := r.tx.PlayerSeason.AddAccumulatedPointsRepo(ctx, r.playerSeasonIDs[playerID], delta)
+		if err != nil {
+			return err
+		}
+		r.result.Points = append(r.result.Points, models.AmendedPoints{
+			PlayerSeasonID: r.playerSeasonIDs[playerID],
+			PlayerID:       playerID,
+			PointsBefore:   total - delta,
+			PointsAfter:    total,
+		})
+	}
+	return nil
+}
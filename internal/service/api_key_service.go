@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"backend-ping-pong-app/internal/auth"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+// Window of GET /api-keys/{keyId}/usage, in days
+const (
+	apiKeyUsageDays    = 30
+	maxAPIKeyUsageDays = 366
+)
+
+type APIKeyService interface {
+	GetAPIKeysService(ctx context.Context) ([]models.APIKey, error)
+	CreateAPIKeyService(ctx context.Context, name string, scopes []string) (*models.APIKeyCreated, error)
+	RevokeAPIKeyService(ctx context.Context, id string) (*models.APIKey, error)
+	GetAPIKeyUsageService(ctx context.Context, id string, days int) ([]models.APIKeyUsage, error)
+	AuthenticateAPIKeyService(ctx context.Context, key string) (*auth.Identity, error)
+}
+
+type apiKeyService struct {
+	repo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(repo repository.APIKeyRepository) APIKeyService {
+	return &apiKeyService{repo: repo}
+}
+
+func (s *apiKeyService) GetAPIKeysService(ctx context.Context) ([]models.APIKey, error) {
+	keys, err := s.repo.GetAPIKeysRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if keys == nil {
+		keys = []models.APIKey{}
+	}
+	return keys, nil
+}
+
+// CreateAPIKeyService issues a key with the given scopes. The key is returned
+// this once; only its hash is stored.
+func (s *apiKeyService) CreateAPIKeyService(ctx context.Context, name string, scopes []string) (*models.APIKeyCreated, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, apperrors.InvalidInput("Tên API key không được để trống")
+	}
+
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !auth.IsScope(scope) {
+			return nil, apperrors.InvalidInput("Phạm vi API key không hợp lệ").WithDetails(map[string]interface{}{
+				"scope":   scope,
+				"allowed": auth.Scopes,
+			})
+		}
+		if !contains(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, apperrors.InvalidInput("API key cần ít nhất một phạm vi")
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{Name: name, Prefix: prefix, Scopes: unique}
+	if identity, ok := auth.IdentityFromContext(ctx); ok && identity.AdminID != "" {
+		apiKey.CreatedBy = &identity.AdminID
+	}
+	if err := s.repo.CreateAPIKeyRepo(ctx, apiKey, hash); err != nil {
+		return nil, err
+	}
+
+	return &models.APIKeyCreated{APIKey: *apiKey, Key: key}, nil
+}
+
+// RevokeAPIKeyService revokes a key from its next request on; revoking it
+// again changes nothing
+func (s *apiKeyService) RevokeAPIKeyService(ctx context.Context, id string) (*models.APIKey, error) {
+	key, err := s.repo.RevokeAPIKeyRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, apperrors.APIKeyNotFound()
+	}
+	return key, nil
+}
+
+// GetAPIKeyUsageService returns the daily request counts of a key over the
+// last days, 30 when days is not positive and a year at most
+func (s *apiKeyService) GetAPIKeyUsageService(ctx context.Context, id string, days int) ([]models.APIKeyUsage, error) {
+	key, err := s.repo.GetAPIKeyByIDRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, apperrors.APIKeyNotFound()
+	}
+
+	if days <= 0 {
+		days = apiKeyUsageDays
+	}
+	if days > maxAPIKeyUsageDays {
+		days = maxAPIKeyUsageDays
+	}
+	usage, err := s.repo.GetAPIKeyUsageRepo(ctx, key.ID, days)
+	if err != nil {
+		return nil, err
+	}
+
+	if usage == nil {
+		usage = []models.APIKeyUsage{}
+	}
+	return usage, nil
+}
+
+// AuthenticateAPIKeyService resolves the integration behind a key, counting
+// the request against it
+func (s *apiKeyService) AuthenticateAPIKeyService(ctx context.Context, key string) (*auth.Identity, error) {
+	if !auth.LooksLikeAPIKey(key) {
+		return nil, apperrors.InvalidAPIKey()
+	}
+
+	apiKey, err := s.repo.UseAPIKeyRepo(ctx, auth.HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil {
+		return nil, apperrors.InvalidAPIKey()
+	}
+
+	scopes := make([]auth.Scope, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, auth.Scope(scope))
+	}
+	return &auth.Identity{APIKeyID: apiKey.ID, Role: auth.RoleAPIKey, Scopes: scopes}, nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"backend-ping-pong-app/internal/auth"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type AuthService interface {
+	LoginService(ctx context.Context, email, password string) (*models.AuthTokens, error)
+	RefreshService(ctx context.Context, refreshToken string) (*models.AuthTokens, error)
+	AuthenticateService(ctx context.Context, accessToken string) (*auth.Identity, error)
+	ChangePasswordService(ctx context.Context, adminID, currentPassword, newPassword string) (*models.AuthTokens, error)
+}
+
+type authService struct {
+	repo       repository.AdminRepository
+	playerRepo repository.PlayerRepository
+	tokens     *auth.TokenIssuer
+	firebase   *auth.FirebaseVerifier // nil when player sign-in is off
+}
+
+func NewAuthService(repo repository.AdminRepository, playerRepo repository.PlayerRepository, tokens *auth.TokenIssuer, firebase *auth.FirebaseVerifier) AuthService {
+	return &authService{repo: repo, playerRepo: playerRepo, tokens: tokens, firebase: firebase}
+}
+
+// dummyHash is compared against when the email is unknown so a failed login
+// takes as long whether or not the account exists
+var dummyHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("not a password")
+	return hash
+})
+
+// LoginService checks an admin's email and password and issues a token pair.
+// Unknown, disabled and wrong password accounts get the same error.
+func (s *authService) LoginService(ctx context.Context, email, password string) (*models.AuthTokens, error) {
+	admin, err := s.repo.GetAdminByEmailRepo(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil, err
+	}
+
+	if admin == nil {
+		auth.VerifyPassword(dummyHash(), password)
+		return nil, apperrors.InvalidCredentials()
+	}
+	if !auth.VerifyPassword(admin.PasswordHash, password) || !admin.IsActive {
+		return nil, apperrors.InvalidCredentials()
+	}
+
+	return s.issueTokens(admin)
+}
+
+// RefreshService exchanges a refresh token for a new token pair, picking up
+// any role change made since the previous login
+func (s *authService) RefreshService(ctx context.Context, refreshToken string) (*models.AuthTokens, error) {
+	admin, err := s.verify(ctx, refreshToken, auth.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(admin)
+}
+
+// AuthenticateService resolves the caller behind an access token: an admin
+// token, or a Firebase ID token of the mobile app. The admin account is read
+// again so disabling it takes effect before the token expires.
+func (s *authService) AuthenticateService(ctx context.Context, accessToken string) (*auth.Identity, error) {
+	if s.firebase != nil && auth.IsFirebaseToken(accessToken) {
+		return s.authenticatePlayer(ctx, accessToken)
+	}
+
+	admin, err := s.verify(ctx, accessToken, auth.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := adminIdentity(admin)
+	return &identity, nil
+}
+
+// ChangePasswordService replaces an admin's password, ending the forced
+// rotation of a temporary one. Every other session is signed out; the
+// returned token pair is the only one valid afterwards.
+func (s *authService) ChangePasswordService(ctx context.Context, adminID, currentPassword, newPassword string) (*models.AuthTokens, error) {
+	admin, err := s.repo.GetAdminByIDRepo(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil || !admin.IsActive {
+		return nil, apperrors.Unauthorized()
+	}
+	if !auth.VerifyPassword(admin.PasswordHash, currentPassword) {
+		return nil, apperrors.InvalidCredentials()
+	}
+
+	if newPassword == currentPassword {
+		return nil, apperrors.InvalidInput("Mật khẩu mới phải khác mật khẩu hiện tại")
+	}
+	hash, err := hashValidPassword(newPassword, admin.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAdminPasswordRepo(ctx, admin.ID, hash, false); err != nil {
+		return nil, err
+	}
+
+	admin, err = s.repo.GetAdminByIDRepo(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, apperrors.Unauthorized()
+	}
+	return s.issueTokens(admin)
+}
+
+// authenticatePlayer verifies a Firebase ID token and maps its UID to the
+// linked player. A user signing in for the first time with a verified phone
+// number is linked to the one player registered with that number. Users that
+// cannot be linked keep an identity without a player.
+func (s *authService) authenticatePlayer(ctx context.Context, idToken string) (*auth.Identity, error) {
+	token, err := s.firebase.Verify(ctx, idToken)
+	if errors.Is(err, auth.ErrExpiredToken) {
+		return nil, apperrors.TokenExpired()
+	}
+	if errors.Is(err, auth.ErrKeysUnavailable) {
+		return nil, apperrors.AuthUnavailable().WithCause(err)
+	}
+	if err != nil {
+		return nil, apperrors.Unauthorized()
+	}
+
+	player, err := s.playerRepo.GetPlayerByFirebaseUIDRepo(ctx, token.UID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil && token.PhoneNumber != "" {
+		player, err = s.linkPlayerByPhone(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identity := &auth.Identity{FirebaseUID: token.UID, Email: token.Email, Role: auth.RolePlayer}
+	if player != nil && player.IsActive {
+		identity.PlayerID = player.ID
+	}
+	return identity, nil
+}
+
+func (s *authService) linkPlayerByPhone(ctx context.Context, token *auth.FirebaseToken) (*models.Player, error) {
+	players, err := s.playerRepo.GetUnlinkedPlayersByPhoneRepo(ctx, phoneVariants(token.PhoneNumber))
+	if err != nil {
+		return nil, err
+	}
+	if len(players) != 1 {
+		return nil, nil
+	}
+
+	player := players[0]
+	if _, err := s.playerRepo.LinkFirebaseUIDRepo(ctx, player.ID, &token.UID); err != nil {
+		return nil, err
+	}
+	player.FirebaseUID = &token.UID
+	return &player, nil
+}
+
+// phoneVariants returns the digits of an E.164 phone number in both the
+// international and the local form players are registered with, e.g.
+// +84912345678 as 84912345678 and 0912345678
+func phoneVariants(phone string) []string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+
+	variants := []string{digits}
+	if local, ok := strings.CutPrefix(digits, "84"); ok && local != "" {
+		variants = append(variants, "0"+local)
+	}
+	return variants
+}
+
+func (s *authService) verify(ctx context.Context, token, tokenType string) (*models.Admin, error) {
+	claims, err := s.tokens.Verify(token, tokenType)
+	if errors.Is(err, auth.ErrExpiredToken) {
+		return nil, apperrors.TokenExpired()
+	}
+	if err != nil {
+		return nil, apperrors.Unauthorized()
+	}
+
+	admin, err := s.repo.GetAdminByIDRepo(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil || !admin.IsActive || claims.Version != passwordVersion(admin) {
+		return nil, apperrors.Unauthorized()
+	}
+
+	return admin, nil
+}
+
+func (s *authService) issueTokens(admin *models.Admin) (*models.AuthTokens, error) {
+	identity := adminIdentity(admin)
+
+	access, expiresAt, err := s.tokens.Issue(identity, auth.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+	refresh, refreshExpiresAt, err := s.tokens.Issue(identity, auth.TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthTokens{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		TokenType:        "Bearer",
+		ExpiresAt:        expiresAt,
+		RefreshExpiresAt: refreshExpiresAt,
+		Admin:            *admin,
+	}, nil
+}
+
+func adminIdentity(admin *models.Admin) auth.Identity {
+	return auth.Identity{
+		AdminID:            admin.ID,
+		Email:              admin.Email,
+		Role:               admin.Role,
+		MustChangePassword: admin.MustChangePassword,
+		PasswordVersion:    passwordVersion(admin),
+	}
+}
+
+// passwordVersion identifies the current password of an admin by the time it
+// was set
+func passwordVersion(admin *models.Admin) int64 {
+	return admin.PasswordChangedAt.UnixMicro()
+}
+
+// hashValidPassword checks password against the policy and hashes it
+func hashValidPassword(password, email string) (string, error) {
+	if err := auth.ValidatePassword(password, email); err != nil {
+		return "", apperrors.WeakPassword().WithDetails(map[string]interface{}{"reason": err.Error()})
+	}
+	return auth.HashPassword(password)
+}
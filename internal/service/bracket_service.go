@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type BracketService interface {
+	GetBracketsService(ctx context.Context, seasonID string) ([]models.Bracket, error)
+	GetBracketTreeService(ctx context.Context, bracketID string) (*models.BracketTree, error)
+	CreateBracketService(ctx context.Context, seasonID string, req models.CreateBracketRequest) (*models.BracketTree, error)
+}
+
+type bracketService struct {
+	store *repository.Repository
+}
+
+func NewBracketService(store *repository.Repository) BracketService {
+	return &bracketService{store: store}
+}
+
+func (s *bracketService) GetBracketsService(ctx context.Context, seasonID string) ([]models.Bracket, error) {
+	brackets, err := s.store.Bracket.GetBracketsBySeasonRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if brackets == nil {
+		brackets = []models.Bracket{}
+	}
+	return brackets, nil
+}
+
+func (s *bracketService) GetBracketTreeService(ctx context.Context, bracketID string) (*models.BracketTree, error) {
+	bracket, err := s.store.Bracket.GetBracketByIDRepo(ctx, bracketID)
+	if err != nil {
+		return nil, err
+	}
+	if bracket == nil {
+		return nil, apperrors.BracketNotFound()
+	}
+
+	return buildBracketTree(ctx, s.store, bracket)
+}
+
+// CreateBracketService seeds the top teams of the season into a single
+// elimination bracket. The field is padded to a power of two with byes given
+// to the best seeds, and fixtures are created for every first round pairing.
+func (s *bracketService) CreateBracketService(ctx context.Context, seasonID string, req models.CreateBracketRequest) (*models.BracketTree, error) {
+	if req.SeedSource == "" {
+		req.SeedSource = models.SeedSourceStandings
+	}
+	if req.SeedSource != models.SeedSourceStandings && req.SeedSource != models.SeedSourceLeaderboard {
+		return nil, apperrors.InvalidInput("seed_source phải là STANDINGS hoặc LEADERBOARD")
+	}
+	if req.Entries < 0 || req.StartRound < 0 {
+		return nil, apperrors.InvalidInput("entries và start_round không được là số âm")
+	}
+
+	season, err := s.store.Season.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	var bracket *models.Bracket
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		seeds, err := bracketSeeds(ctx, tx, seasonID, req.SeedSource)
+		if err != nil {
+			return err
+		}
+
+		if req.Entries == 0 {
+			req.Entries = len(seeds)
+		}
+		if req.Entries < 2 || req.Entries > len(seeds) {
+			return apperrors.InvalidInput("Số đội tham gia phải từ 2 đến số đội của mùa giải")
+		}
+		if req.ThirdPlace && req.Entries < 4 {
+			return apperrors.InvalidInput("Trận tranh hạng ba cần ít nhất 4 đội")
+		}
+
+		startRound, err := bracketStartRound(ctx, tx, seasonID, req.StartRound)
+		if err != nil {
+			return err
+		}
+
+		bracket, err = tx.Bracket.CreateBracketRepo(ctx, &models.Bracket{
+			SeasonID:   seasonID,
+			Name:       req.Name,
+			Size:       bracketSize(req.Entries),
+			Entries:    req.Entries,
+			SeedSource: req.SeedSource,
+			ThirdPlace: req.ThirdPlace,
+			StartRound: startRound,
+		})
+		if err != nil {
+			return err
+		}
+
+		return seedBracket(ctx, tx, bracket, seeds[:req.Entries])
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildBracketTree(ctx, s.store, bracket)
+}
+
+// bracketSeeds returns the season's team ids, best seed first
+func bracketSeeds(ctx context.Context, tx *repository.Repository, seasonID, source string) ([]string, error) {
+	if source == models.SeedSourceLeaderboard {
+		return tx.Bracket.GetTeamsByLeaderboardRepo(ctx, seasonID)
+	}
+
+	standings, err := computeTeamStandings(ctx, tx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	teamIDs := make([]string, len(standings))
+	for i, standing := range standings {
+		teamIDs[i] = standing.TeamID
+	}
+	return teamIDs, nil
+}
+
+// bracketStartRound defaults to the round after the season's last fixture and
+// refuses rounds that are already finalized
+func bracketStartRound(ctx context.Context, tx *repository.Repository, seasonID string, startRound int) (int, error) {
+	if startRound == 0 {
+		fixtures, err := tx.Fixture.GetFixturesBySeasonRepo(ctx, seasonID, models.FixtureFilter{})
+		if err != nil {
+			return 0, err
+		}
+
+		startRound = 1
+		if len(fixtures) > 0 {
+			startRound = fixtures[len(fixtures)-1].Round + 1
+		}
+	}
+
+	latest, err := tx.Round.GetLatestFinalizedRoundRepo(ctx, seasonID)
+	if err != nil {
+		return 0, err
+	}
+	if startRound <= latest {
+		return 0, apperrors.RoundAlreadyFinalized()
+	}
+
+	return startRound, nil
+}
+
+// bracketSize is the smallest power of two that fits every entry
+func bracketSize(entries int) int {
+	size := 1
+	for size < entries {
+		size *= 2
+	}
+	return size
+}
+
+// bracketRounds is the number of rounds up to and including the final
+func bracketRounds(size int) int {
+	rounds := 0
+	for size > 1 {
+		size /= 2
+		rounds++
+	}
+	return rounds
+}
+
+// seedOrder lists the seeds in first round slot order so that seeds 1 and 2
+// can only meet in the final: 1, 8, 4, 5, 2, 7, 3, 6 for a size of 8
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// seedBracket creates every node of the bracket tree, places the seeds in the
+// first round and starts the first round matches
+func seedBracket(ctx context.Context, tx *repository.Repository, bracket *models.Bracket, teamIDs []string) error {
+	rounds := bracketRounds(bracket.Size)
+	for round := 2; round <= rounds; round++ {
+		for position := 0; position < bracket.Size>>round; position++ {
+			node := &models.BracketMatch{BracketID: bracket.ID, Round: round, Position: position}
+			if _, err := tx.Bracket.CreateBracketMatchRepo(ctx, node); err != nil {
+				return err
+			}
+		}
+	}
+	if bracket.ThirdPlace {
+		node := &models.BracketMatch{BracketID: bracket.ID, Round: rounds, ThirdPlace: true}
+		if _, err := tx.Bracket.CreateBracketMatchRepo(ctx, node); err != nil {
+			return err
+		}
+	}
+
+	order := seedOrder(bracket.Size)
+	for position := 0; position < bracket.Size/2; position++ {
+		homeSeed, guestSeed := order[2*position], order[2*position+1]
+		node := &models.BracketMatch{
+			BracketID: bracket.ID,
+			Round:     1,
+			Position:  position,
+			HomeSeed:  &homeSeed,
+			GuestSeed: &guestSeed,
+		}
+		// Seeds beyond the entries are byes
+		if homeSeed <= len(teamIDs) {
+			node.HomeTeamID = &teamIDs[homeSeed-1]
+		}
+		if guestSeed <= len(teamIDs) {
+			node.GuestTeamID = &teamIDs[guestSeed-1]
+		}
+
+		if _, err := tx.Bracket.CreateBracketMatchRepo(ctx, node); err != nil {
+			return err
+		}
+		if err := startBracketMatch(ctx, tx, bracket, node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startBracketMatch creates the fixture of a node once both teams are known.
+// A first round node with a single team is a bye and is won straight away.
+func startBracketMatch(ctx context.Context, tx *repository.Repository, bracket *models.Bracket, node *models.BracketMatch) error {
+	if node.FixtureID != nil || node.WinnerTeamID != nil {
+		return nil
+	}
+
+	if node.HomeTeamID != nil && node.GuestTeamID != nil {
+		fixture, err := tx.Fixture.CreateFixtureRepo(ctx, &models.Fixture{
+			SeasonID:    bracket.SeasonID,
+			Round:       bracket.StartRound + node.Round - 1,
+			HomeTeamID:  *node.HomeTeamID,
+			GuestTeamID: *node.GuestTeamID,
+		})
+		if err != nil {
+			return err
+		}
+
+		node.FixtureID = &fixture.ID
+		return tx.Bracket.UpdateBracketMatchRepo(ctx, node)
+	}
+
+	if node.Round != 1 {
+		return nil
+	}
+
+	if node.HomeTeamID != nil {
+		node.WinnerTeamID = node.HomeTeamID
+	} else {
+		node.WinnerTeamID = node.GuestTeamID
+	}
+	if err := tx.Bracket.UpdateBracketMatchRepo(ctx, node); err != nil {
+		return err
+	}
+	return advanceBracketWinner(ctx, tx, bracket, node)
+}
+
+// advanceBracket records the winner of a completed playoff fixture and moves
+// them to the next round. Fixtures outside any bracket are ignored.
+func advanceBracket(ctx context.Context, tx *repository.Repository, fixture *models.Fixture) error {
+	node, err := tx.Bracket.GetBracketMatchByFixtureRepo(ctx, fixture.ID)
+	if err != nil || node == nil || node.WinnerTeamID != nil {
+		return err
+	}
+
+	switch {
+	case fixture.HomeScore > fixture.GuestScore:
+		node.WinnerTeamID = &fixture.HomeTeamID
+	case fixture.GuestScore > fixture.HomeScore:
+		node.WinnerTeamID = &fixture.GuestTeamID
+	default:
+		return apperrors.KnockoutDraw()
+	}
+
+	if err := tx.Bracket.UpdateBracketMatchRepo(ctx, node); err != nil {
+		return err
+	}
+
+	bracket, err := tx.Bracket.GetBracketByIDRepo(ctx, node.BracketID)
+	if err != nil {
+		return err
+	}
+	return advanceBracketWinner(ctx, tx, bracket, node)
+}
+
+// advanceBracketWinner places the winner of a node in the next round, and the
+// loser of a semi final in the third place match. After the last match the
+// bracket is completed.
+func advanceBracketWinner(ctx context.Context, tx *repository.Repository, bracket *models.Bracket, node *models.BracketMatch) error {
+	rounds := bracketRounds(bracket.Size)
+	if node.Round == rounds {
+		return completeBracket(ctx, tx, bracket)
+	}
+
+	winnerSeed, loserID, loserSeed := node.HomeSeed, node.GuestTeamID, node.GuestSeed
+	if *node.WinnerTeamID != derefString(node.HomeTeamID) {
+		winnerSeed, loserID, loserSeed = node.GuestSeed, node.HomeTeamID, node.HomeSeed
+	}
+
+	if err := placeInBracket(ctx, tx, bracket, node.Round+1, node.Position/2, false, node.Position%2 == 0, node.WinnerTeamID, winnerSeed); err != nil {
+		return err
+	}
+
+	if bracket.ThirdPlace && node.Round == rounds-1 && loserID != nil {
+		return placeInBracket(ctx, tx, bracket, rounds, 0, true, node.Position%2 == 0, loserID, loserSeed)
+	}
+	return nil
+}
+
+func placeInBracket(ctx context.Context, tx *repository.Repository, bracket *models.Bracket, round, position int, thirdPlace, home bool, teamID *string, seed *int) error {
+	next, err := tx.Bracket.LockBracketMatchRepo(ctx, bracket.ID, round, position, thirdPlace)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		return apperrors.BracketNotFound()
+	}
+
+	if home {
+		next.HomeTeamID, next.HomeSeed = teamID, seed
+	} else {
+		next.GuestTeamID, next.GuestSeed = teamID, seed
+	}
+	if err := tx.Bracket.UpdateBracketMatchRepo(ctx, next); err != nil {
+		return err
+	}
+
+	return startBracketMatch(ctx, tx, bracket, next)
+}
+
+// completeBracket marks the bracket completed once the final and the third
+// place match have a winner
+func completeBracket(ctx context.Context, tx *repository.Repository, bracket *models.Bracket) error {
+	rounds := bracketRounds(bracket.Size)
+	nodes, err := tx.Bracket.GetBracketMatchesRepo(ctx, bracket.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if node.Round == rounds && node.WinnerTeamID == nil {
+			return nil
+		}
+	}
+
+	return tx.Bracket.UpdateBracketStatusRepo(ctx, bracket.ID, models.BracketStatusCompleted)
+}
+
+// buildBracketTree groups the nodes by round and links every node to the one
+// its winner moves to
+func buildBracketTree(ctx context.Context, repo *repository.Repository, bracket *models.Bracket) (*models.BracketTree, error) {
+	nodes, err := repo.Bracket.GetBracketMatchesRepo(ctx, bracket.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := bracketRounds(bracket.Size)
+	tree := &models.BracketTree{
+		Bracket: *bracket,
+		Rounds:  make([]models.BracketRound, rounds),
+	}
+	for i := range tree.Rounds {
+		tree.Rounds[i] = models.BracketRound{Round: i + 1, Matches: []models.BracketMatch{}}
+	}
+
+	for _, node := range nodes {
+		if node.ThirdPlace {
+			third := node
+			tree.ThirdPlaceMatch = &third
+			continue
+		}
+		tree.Rounds[node.Round-1].Matches = append(tree.Rounds[node.Round-1].Matches, node)
+	}
+
+	// Nodes come ordered by position, so the parent of position p is at p/2
+	for round := 0; round < rounds-1; round++ {
+		parents := tree.Rounds[round+1].Matches
+		for i := range tree.Rounds[round].Matches {
+			node := &tree.Rounds[round].Matches[i]
+			if parent := node.Position / 2; parent < len(parents) {
+				node.NextMatchID = &parents[parent].ID
+			}
+		}
+	}
+
+	return tree, nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
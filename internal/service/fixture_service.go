@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type FixtureService interface {
+	GetFixturesBySeasonService(ctx context.Context, seasonID string, filter models.FixtureFilter) ([]models.Fixture, error)
+	GetFixtureByIDService(ctx context.Context, seasonID, id string) (*models.Fixture, error)
+	CreateFixtureService(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error)
+	UpdateFixtureService(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error)
+	CancelFixtureService(ctx context.Context, seasonID, id string) (*models.Fixture, error)
+}
+
+type fixtureService struct {
+	repo     repository.FixtureRepository
+	teamRepo repository.TeamRepository
+}
+
+func NewFixtureService(repo repository.FixtureRepository, teamRepo repository.TeamRepository) FixtureService {
+	return &fixtureService{repo: repo, teamRepo: teamRepo}
+}
+
+func (s *fixtureService) GetFixturesBySeasonService(ctx context.Context, seasonID string, filter models.FixtureFilter) ([]models.Fixture, error) {
+	if filter.Status != "" && !models.IsValidFixtureStatus(filter.Status) {
+		return nil, apperrors.InvalidInput("status không hợp lệ")
+	}
+	if filter.Round != nil && *filter.Round <= 0 {
+		return nil, apperrors.InvalidInput("round phải lớn hơn 0")
+	}
+
+	fixtures, err := s.repo.GetFixturesBySeasonRepo(ctx, seasonID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixtures == nil {
+		fixtures = []models.Fixture{}
+	}
+	return fixtures, nil
+}
+
+func (s *fixtureService) GetFixtureByIDService(ctx context.Context, seasonID, id string) (*models.Fixture, error) {
+	fixture, err := s.repo.GetFixtureByIDRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixture == nil || fixture.SeasonID != seasonID {
+		return nil, apperrors.FixtureNotFound()
+	}
+
+	return fixture, nil
+}
+
+func (s *fixtureService) CreateFixtureService(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error) {
+	if err := s.validateFixture(ctx, fixture); err != nil {
+		return nil, err
+	}
+
+	fixture.Status = models.FixtureStatusScheduled
+	return s.repo.CreateFixtureRepo(ctx, fixture)
+}
+
+func (s *fixtureService) UpdateFixtureService(ctx context.Context, fixture *models.Fixture) (*models.Fixture, error) {
+	existing, err := s.GetFixtureByIDService(ctx, fixture.SeasonID, fixture.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only fixtures that have not started can be rescheduled
+	if existing.Status != models.FixtureStatusScheduled {
+		return nil, apperrors.FixtureNotActive()
+	}
+
+	if err := s.validateFixture(ctx, fixture); err != nil {
+		return nil, err
+	}
+
+	existing.Round = fixture.Round
+	existing.HomeTeamID = fixture.HomeTeamID
+	existing.GuestTeamID = fixture.GuestTeamID
+	existing.LineupDeadline = fixture.LineupDeadline
+
+	return s.repo.UpdateFixtureRepo(ctx, existing)
+}
+
+func (s *fixtureService) CancelFixtureService(ctx context.Context, seasonID, id string) (*models.Fixture, error) {
+	fixture, err := s.GetFixtureByIDService(ctx, seasonID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixture.Status != models.FixtureStatusScheduled {
+		return nil, apperrors.FixtureNotActive()
+	}
+
+	fixture.Status = models.FixtureStatusCancelled
+	return s.repo.UpdateFixtureRepo(ctx, fixture)
+}
+
+// validateFixture checks the round and that both teams exist in the fixture's season
+func (s *fixtureService) validateFixture(ctx context.Context, fixture *models.Fixture) error {
+	if fixture.Round <= 0 {
+		return apperrors.InvalidInput("round phải lớn hơn 0")
+	}
+
+	if fixture.HomeTeamID == fixture.GuestTeamID {
+		return apperrors.SameTeamMatch()
+	}
+
+	for _, teamID := range []string{fixture.HomeTeamID, fixture.GuestTeamID} {
+		team, err := s.teamRepo.GetTeamByIDRepo(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		if team == nil || team.SeasonID != fixture.SeasonID {
+			return apperrors.TeamNotFound().WithDetails(map[string]string{"team_id": teamID})
+		}
+	}
+
+	return nil
+}
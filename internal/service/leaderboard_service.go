@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+	"backend-ping-pong-app/internal/utils"
+)
+
+type LeaderboardService interface {
+	GetSeasonLeaderboardService(ctx context.Context, filter models.LeaderboardFilter, page utils.Pagination) (*models.LeaderboardResponse, error)
+	GetRoundLeaderboardService(ctx context.Context, filter models.RoundLeaderboardFilter, page utils.Pagination) (*models.RoundLeaderboardResponse, error)
+}
+
+type leaderboardService struct {
+	repo      repository.LeaderboardRepository
+	roundRepo repository.RoundRepository
+}
+
+func NewLeaderboardService(repo repository.LeaderboardRepository, roundRepo repository.RoundRepository) LeaderboardService {
+	return &leaderboardService{repo: repo, roundRepo: roundRepo}
+}
+
+func (s *leaderboardService) GetSeasonLeaderboardService(ctx context.Context, filter models.LeaderboardFilter, page utils.Pagination) (*models.LeaderboardResponse, error) {
+	filter.Limit = page.PageSize
+	filter.Offset = page.Offset()
+
+	entries, total, err := s.repo.GetLeaderboardRepo(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// The live view is always current; the materialized one is as old as its last refresh
+	var materializedAt *time.Time
+	if filter.Fresh {
+		now := time.Now()
+		materializedAt = &now
+	} else {
+		materializedAt, err = s.repo.GetMaterializedAtRepo(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range entries {
+		if entries[i].AvatarURL != nil {
+			url := utils.BuildCDNURL(*entries[i].AvatarURL)
+			entries[i].AvatarURL = &url
+		}
+	}
+	if entries == nil {
+		entries = []models.LeaderboardEntry{}
+	}
+
+	return &models.LeaderboardResponse{
+		SeasonID:       filter.SeasonID,
+		Fresh:          filter.Fresh,
+		MaterializedAt: materializedAt,
+		Page:           page.Page,
+		PageSize:       page.PageSize,
+		Total:          total,
+		Entries:        entries,
+	}, nil
+}
+
+// GetRoundLeaderboardService returns the standings snapshot of a finalized
+// round with the movement of every player since the previous snapshot
+func (s *leaderboardService) GetRoundLeaderboardService(ctx context.Context, filter models.RoundLeaderboardFilter, page utils.Pagination) (*models.RoundLeaderboardResponse, error) {
+	round, err := s.roundRepo.GetRoundRepo(ctx, filter.SeasonID, filter.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+	if round == nil || round.Status != models.RoundStatusFinalized {
+		return nil, apperrors.RoundNotFinalized()
+	}
+
+	previousRound, err := s.repo.GetPreviousSnapshotRoundRepo(ctx, filter.SeasonID, filter.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = page.PageSize
+	filter.Offset = page.Offset()
+
+	entries, total, err := s.repo.GetRoundLeaderboardRepo(ctx, filter, previousRound)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if entries[i].AvatarURL != nil {
+			url := utils.BuildCDNURL(*entries[i].AvatarURL)
+			entries[i].AvatarURL = &url
+		}
+		setMovement(&entries[i])
+	}
+	if entries == nil {
+		entries = []models.RoundLeaderboardEntry{}
+	}
+
+	return &models.RoundLeaderboardResponse{
+		SeasonID:      filter.SeasonID,
+		RoundNumber:   filter.RoundNumber,
+		PreviousRound: previousRound,
+		FinalizedAt:   round.FinalizedAt,
+		Page:          page.Page,
+		PageSize:      page.PageSize,
+		Total:         total,
+		Entries:       entries,
+	}, nil
+}
+
+// setMovement compares the entry's position with the previous snapshot.
+// Players missing from the previous snapshot are NEW.
+func setMovement(entry *models.RoundLeaderboardEntry) {
+	if entry.PreviousPosition == nil {
+		entry.Movement = models.MovementNew
+		return
+	}
+
+	entry.PositionChange = *entry.PreviousPosition - entry.Position
+	switch {
+	case entry.PositionChange > 0:
+		entry.Movement = models.MovementUp
+	case entry.PositionChange < 0:
+		entry.Movement = models.MovementDown
+	default:
+		entry.Movement = models.MovementSame
+	}
+}
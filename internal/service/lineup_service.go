@@ -0,0 +1,488 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"backend-ping-pong-app/internal/auth"
+	"backend-ping-pong-app/internal/config"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/handicap"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type LineupService interface {
+	GetFixtureLineupsService(ctx context.Context, seasonID, fixtureID string) ([]models.Lineup, error)
+	SubmitLineupService(ctx context.Context, seasonID string, lineup *models.Lineup) (*models.Lineup, error)
+	LockLineupsService(ctx context.Context, seasonID, fixtureID string) (*models.LineupLock, error)
+	LockDueLineupsService(ctx context.Context, now time.Time) (int, error)
+}
+
+type lineupService struct {
+	store  *repository.Repository
+	league config.LeagueConfig
+}
+
+func NewLineupService(store *repository.Repository, league config.LeagueConfig) LineupService {
+	return &lineupService{store: store, league: league}
+}
+
+// GetFixtureLineupsService returns the lineups of a fixture. Teams commit
+// blind, so until the lineups are revealed callers only see their own.
+func (s *lineupService) GetFixtureLineupsService(ctx context.Context, seasonID, fixtureID string) ([]models.Lineup, error) {
+	fixture, err := s.store.Fixture.GetFixtureByIDRepo(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	if fixture == nil || fixture.SeasonID != seasonID {
+		return nil, apperrors.FixtureNotFound()
+	}
+
+	lineups, err := s.store.Lineup.GetLineupsByFixtureRepo(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lineupsRevealed(fixture, lineups, time.Now()) {
+		if lineups, err = ownLineups(ctx, s.store, lineups); err != nil {
+			return nil, err
+		}
+	}
+
+	if lineups == nil {
+		lineups = []models.Lineup{}
+	}
+	return lineups, nil
+}
+
+// SubmitLineupService creates or replaces a team's lineup for a scheduled
+// fixture until it is locked or the fixture's lineup deadline has passed
+func (s *lineupService) SubmitLineupService(ctx context.Context, seasonID string, lineup *models.Lineup) (*models.Lineup, error) {
+	if err := s.validateLineupFormat(lineup); err != nil {
+		return nil, err
+	}
+
+	var saved *models.Lineup
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		// Lock the fixture so a submission cannot race the lineup lock
+		fixture, err := tx.Fixture.LockFixtureRepo(ctx, lineup.FixtureID)
+		if err != nil {
+			return err
+		}
+		if fixture == nil || fixture.SeasonID != seasonID {
+			return apperrors.FixtureNotFound()
+		}
+
+		if fixture.Status != models.FixtureStatusScheduled {
+			return apperrors.FixtureNotActive()
+		}
+		if lineup.TeamID != fixture.HomeTeamID && lineup.TeamID != fixture.GuestTeamID {
+			return apperrors.InvalidTeamMatch()
+		}
+		if err := authorizeLineupTeam(ctx, tx, lineup.TeamID); err != nil {
+			return err
+		}
+
+		if err := ensureRoundOpen(ctx, tx, seasonID, fixture.Round); err != nil {
+			return err
+		}
+
+		if fixture.LineupDeadline != nil && !time.Now().Before(*fixture.LineupDeadline) {
+			return apperrors.LineupLocked().WithDetails(map[string]interface{}{"lineup_deadline": fixture.LineupDeadline})
+		}
+
+		lineups, err := tx.Lineup.GetLineupsByFixtureRepo(ctx, fixture.ID)
+		if err != nil {
+			return err
+		}
+		for _, existing := range lineups {
+			if existing.TeamID == lineup.TeamID && existing.Status == models.LineupStatusLocked {
+				return apperrors.LineupLocked()
+			}
+		}
+
+		settings, err := loadSeasonSettings(ctx, tx.Settings, seasonID)
+		if err != nil {
+			return err
+		}
+
+		if err := validateLineupPlayers(ctx, tx, fixture, lineup, settings.MaxAppearancesPerFixture); err != nil {
+			return err
+		}
+
+		saved, err = tx.Lineup.SaveLineupRepo(ctx, lineup)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// LockLineupsService locks the submitted lineups of a fixture ahead of its
+// deadline, pre-filling the rubbers once both teams are locked
+func (s *lineupService) LockLineupsService(ctx context.Context, seasonID, fixtureID string) (*models.LineupLock, error) {
+	var result *models.LineupLock
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		fixture, err := tx.Fixture.LockFixtureRepo(ctx, fixtureID)
+		if err != nil {
+			return err
+		}
+		if fixture == nil || fixture.SeasonID != seasonID {
+			return apperrors.FixtureNotFound()
+		}
+
+		if fixture.Status != models.FixtureStatusScheduled {
+			return apperrors.FixtureNotActive()
+		}
+
+		if err := ensureRoundOpen(ctx, tx, seasonID, fixture.Round); err != nil {
+			return err
+		}
+
+		result, err = s.lockLineups(ctx, tx, fixture)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// LockDueLineupsService locks the lineups of every fixture whose lineup
+// deadline has passed and returns the number of fixtures locked
+func (s *lineupService) LockDueLineupsService(ctx context.Context, now time.Time) (int, error) {
+	fixtures, err := s.store.Fixture.GetFixturesDueForLineupLockRepo(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	locked := 0
+	for _, due := range fixtures {
+		err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+			fixture, err := tx.Fixture.LockFixtureRepo(ctx, due.ID)
+			if err != nil {
+				return err
+			}
+			// Started or cancelled in the meantime
+			if fixture == nil || fixture.Status != models.FixtureStatusScheduled {
+				return nil
+			}
+
+			if _, err := s.lockLineups(ctx, tx, fixture); err != nil {
+				return err
+			}
+			locked++
+			return nil
+		})
+		if err != nil {
+			return locked, err
+		}
+	}
+
+	return locked, nil
+}
+
+// lockLineups locks the submitted lineups of the fixture and, when both teams
+// have a lineup, creates the rubbers that are not recorded yet with the rank
+// and handicap snapshots so the match sheet shows the starting scores
+func (s *lineupService) lockLineups(ctx context.Context, tx *repository.Repository, fixture *models.Fixture) (*models.LineupLock, error) {
+	if err := tx.Lineup.LockLineupsRepo(ctx, fixture.ID); err != nil {
+		return nil, err
+	}
+
+	lineups, err := tx.Lineup.GetLineupsByFixtureRepo(ctx, fixture.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(lineups) == 0 {
+		return nil, apperrors.LineupNotFound()
+	}
+
+	result := &models.LineupLock{Fixture: *fixture, Lineups: lineups, Matches: []models.Match{}}
+
+	var home, guest *models.Lineup
+	for i := range lineups {
+		switch lineups[i].TeamID {
+		case fixture.HomeTeamID:
+			home = &lineups[i]
+		case fixture.GuestTeamID:
+			guest = &lineups[i]
+		}
+	}
+	if home == nil || guest == nil {
+		return result, nil
+	}
+
+	settings, err := loadSeasonSettings(ctx, tx.Settings, fixture.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := tx.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	guestEntries := make(map[int]models.LineupEntry, len(guest.Entries))
+	for _, entry := range guest.Entries {
+		guestEntries[entry.MatchOrder] = entry
+	}
+
+	for _, homeEntry := range home.Entries {
+		guestEntry, ok := guestEntries[homeEntry.MatchOrder]
+		if !ok {
+			continue
+		}
+
+		existing, err := tx.Match.GetMatchByOrderRepo(ctx, fixture.ID, homeEntry.MatchOrder)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			continue
+		}
+
+		match, err := prefillMatch(ctx, tx, fixture, homeEntry, guestEntry, settings.HandicapFormula(), ranks)
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := tx.Match.CreateMatchRepo(ctx, match)
+		if err != nil {
+			return nil, err
+		}
+		result.Matches = append(result.Matches, *created)
+	}
+
+	return result, nil
+}
+
+// prefillMatch builds the rubber two lineup slots play against each other,
+// with the rank and handicap snapshots taken from the players' current ranks
+func prefillMatch(ctx context.Context, repo *repository.Repository, fixture *models.Fixture, home, guest models.LineupEntry, formula *handicap.Formula, ranks []models.Rank) (*models.Match, error) {
+	match := &models.Match{
+		FixtureID:      fixture.ID,
+		MatchOrder:     home.MatchOrder,
+		MatchType:      home.MatchType,
+		HomePlayer1ID:  home.Player1ID,
+		HomePlayer2ID:  home.Player2ID,
+		GuestPlayer1ID: guest.Player1ID,
+		GuestPlayer2ID: guest.Player2ID,
+	}
+
+	playerRanks := make(map[string]string)
+	for _, playerID := range append(match.HomePlayerIDs(), match.GuestPlayerIDs()...) {
+		ps, err := repo.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, fixture.SeasonID, playerID)
+		if err != nil {
+			return nil, err
+		}
+		if ps != nil {
+			playerRanks[playerID] = ps.RankID
+		}
+	}
+
+	var err error
+	if match.RankSnapshot, err = jsonString(playerRanks); err != nil {
+		return nil, err
+	}
+	if match.HandicapSnapshot, err = handicapSnapshot(formula, ranks, playerRanks, match); err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// validateLineupFormat checks that the lineup fills every rubber of the
+// fixture exactly once with the number of players of its match type
+func (s *lineupService) validateLineupFormat(lineup *models.Lineup) error {
+	if len(lineup.Entries) != s.league.RubbersPerFixture {
+		return apperrors.LineupInvalid(fmt.Sprintf("Đội hình phải có đủ %d trận", s.league.RubbersPerFixture))
+	}
+
+	sort.Slice(lineup.Entries, func(i, j int) bool {
+		return lineup.Entries[i].MatchOrder < lineup.Entries[j].MatchOrder
+	})
+
+	for i := range lineup.Entries {
+		entry := &lineup.Entries[i]
+		if entry.MatchOrder != i+1 {
+			return apperrors.LineupInvalid(fmt.Sprintf("match_order phải từ 1 đến %d và không trùng lặp", s.league.RubbersPerFixture))
+		}
+
+		entry.MatchType = models.MatchTypeSingle
+		if s.league.IsDoublesRubber(entry.MatchOrder) {
+			entry.MatchType = models.MatchTypeDouble
+		}
+
+		switch {
+		case entry.MatchType == models.MatchTypeSingle && entry.Player2ID != nil,
+			entry.MatchType == models.MatchTypeDouble && (entry.Player2ID == nil || *entry.Player2ID == entry.Player1ID):
+			return apperrors.LineupInvalid(fmt.Sprintf("Trận %d là trận %s", entry.MatchOrder, entry.MatchType)).
+				WithDetails(map[string]interface{}{"match_order": entry.MatchOrder, "match_type": entry.MatchType})
+		}
+	}
+
+	return nil
+}
+
+// validateLineupPlayers checks every player of the lineup: an ACTIVE season
+// registration, membership of the team at the fixture's round and at most
+// maxAppearances rubbers. Singles players must be ordered strongest first by
+// the rank they hold when they first appear.
+func validateLineupPlayers(ctx context.Context, tx *repository.Repository, fixture *models.Fixture, lineup *models.Lineup, maxAppearances int) error {
+	ranks, err := tx.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return err
+	}
+	sortOrders := make(map[string]int, len(ranks))
+	for _, rank := range ranks {
+		sortOrders[rank.ID] = rank.SortOrder
+	}
+
+	playerRanks := make(map[string]string)
+	appearances := make(map[string]int)
+	singlesOrder := make([]string, 0, len(lineup.Entries))
+
+	for _, entry := range lineup.Entries {
+		for _, playerID := range entry.PlayerIDs() {
+			appearances[playerID]++
+			if appearances[playerID] > maxAppearances {
+				return apperrors.LineupInvalid(fmt.Sprintf("Mỗi VĐV chỉ được thi đấu tối đa %d trận", maxAppearances)).
+					WithDetails(map[string]interface{}{"player_id": playerID, "match_order": entry.MatchOrder})
+			}
+
+			if _, checked := playerRanks[playerID]; !checked {
+				rankID, err := validateLineupPlayer(ctx, tx, fixture, lineup.TeamID, playerID)
+				if err != nil {
+					return err
+				}
+				playerRanks[playerID] = rankID
+			}
+
+			if entry.MatchType == models.MatchTypeSingle && !contains(singlesOrder, playerID) {
+				singlesOrder = append(singlesOrder, playerID)
+			}
+		}
+	}
+
+	// Players are ordered by their first singles rubber: nobody may be
+	// stronger than a teammate who appears before them
+	for i := 1; i < len(singlesOrder); i++ {
+		above, below := singlesOrder[i-1], singlesOrder[i]
+		if sortOrders[playerRanks[below]] > sortOrders[playerRanks[above]] {
+			return apperrors.LineupInvalid("VĐV hạng cao hơn phải được xếp ở vị trí trên").
+				WithDetails(map[string]interface{}{
+					"player_id":       below,
+					"rank_id":         playerRanks[below],
+					"above_player_id": above,
+					"above_rank_id":   playerRanks[above],
+				})
+		}
+	}
+
+	return nil
+}
+
+// validateLineupPlayer checks that the player is ACTIVE in the season and on
+// the team at the fixture's round, and returns their current rank
+func validateLineupPlayer(ctx context.Context, tx *repository.Repository, fixture *models.Fixture, teamID, playerID string) (string, error) {
+	ps, err := tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, fixture.SeasonID, playerID)
+	if err != nil {
+		return "", err
+	}
+	if ps == nil {
+		return "", apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"player_id": playerID})
+	}
+	if ps.Status != models.PlayerSeasonStatusActive {
+		return "", apperrors.PlayerInactive().WithDetails(map[string]string{"player_id": playerID, "status": ps.Status})
+	}
+
+	roundTeamID, err := playerTeamAtRound(ctx, tx, fixture.SeasonID, playerID, fixture.Round)
+	if err != nil {
+		return "", err
+	}
+	if roundTeamID != teamID {
+		return "", apperrors.InvalidPlayers().WithDetails(map[string]interface{}{
+			"player_id": playerID,
+			"team_id":   teamID,
+			"round":     fixture.Round,
+		})
+	}
+
+	return ps.RankID, nil
+}
+
+// lineupsRevealed reports whether the lineups of both teams may be shown:
+// once both are locked or the fixture's lineup deadline has passed
+func lineupsRevealed(fixture *models.Fixture, lineups []models.Lineup, now time.Time) bool {
+	if fixture.LineupDeadline != nil && !now.Before(*fixture.LineupDeadline) {
+		return true
+	}
+
+	locked := 0
+	for _, lineup := range lineups {
+		if lineup.Status == models.LineupStatusLocked && (lineup.TeamID == fixture.HomeTeamID || lineup.TeamID == fixture.GuestTeamID) {
+			locked++
+		}
+	}
+	return locked == 2
+}
+
+// ownLineups keeps the lineups the caller may see before they are revealed:
+// all of them for callers managing lineups, the teams they captain for the
+// others and none for anonymous callers
+func ownLineups(ctx context.Context, repo *repository.Repository, lineups []models.Lineup) ([]models.Lineup, error) {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok || identity.AdminID == "" {
+		return nil, nil
+	}
+	if identity.Can(auth.PermissionManageLineups) {
+		return lineups, nil
+	}
+
+	var own []models.Lineup
+	for _, lineup := range lineups {
+		isCaptain, err := repo.Admin.IsTeamCaptainRepo(ctx, lineup.TeamID, identity.AdminID)
+		if err != nil {
+			return nil, err
+		}
+		if isCaptain {
+			own = append(own, lineup)
+		}
+	}
+	return own, nil
+}
+
+// authorizeLineupTeam lets callers that manage every lineup through and
+// restricts the others, captains, to the teams they captain
+func authorizeLineupTeam(ctx context.Context, tx *repository.Repository, teamID string) error {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return apperrors.Unauthorized()
+	}
+	if identity.Can(auth.PermissionManageLineups) {
+		return nil
+	}
+
+	isCaptain, err := tx.Admin.IsTeamCaptainRepo(ctx, teamID, identity.AdminID)
+	if err != nil {
+		return err
+	}
+	if !isCaptain {
+		return apperrors.Forbidden().WithDetails(map[string]interface{}{"team_id": teamID})
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
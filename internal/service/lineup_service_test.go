@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend-ping-pong-app/internal/auth"
+	"backend-ping-pong-app/internal/config"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type lineupFixtureRepo struct {
+	repository.FixtureRepository
+	fixture *models.Fixture
+}
+
+func (r *lineupFixtureRepo) GetFixtureByIDRepo(ctx context.Context, id string) (*models.Fixture, error) {
+	return r.fixture, nil
+}
+
+type lineupRepo struct {
+	repository.LineupRepository
+	lineups []models.Lineup
+}
+
+func (r *lineupRepo) GetLineupsByFixtureRepo(ctx context.Context, fixtureID string) ([]models.Lineup, error) {
+	return append([]models.Lineup(nil), r.lineups...), nil
+}
+
+// captainRepo knows the team each captain admin leads
+type captainRepo struct {
+	repository.AdminRepository
+	teams map[string]string
+}
+
+func (r *captainRepo) IsTeamCaptainRepo(ctx context.Context, teamID, adminID string) (bool, error) {
+	return r.teams[adminID] == teamID, nil
+}
+
+func TestGetFixtureLineupsHidesSubmittedLineups(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	homeCaptain := &auth.Identity{AdminID: "home-captain", Role: models.AdminRoleCaptain}
+	guestCaptain := &auth.Identity{AdminID: "guest-captain", Role: models.AdminRoleCaptain}
+	moderator := &auth.Identity{AdminID: "moderator", Role: models.AdminRoleModerator}
+	player := &auth.Identity{PlayerID: "player", Role: auth.RolePlayer}
+
+	tests := []struct {
+		name        string
+		deadline    *time.Time
+		homeStatus  string
+		guestStatus string
+		identity    *auth.Identity
+		want        []string
+	}{
+		{
+			name:        "public sees nothing before the lock",
+			deadline:    &future,
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+			want:        []string{},
+		},
+		{
+			name:        "player sees nothing before the lock",
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+			identity:    player,
+			want:        []string{},
+		},
+		{
+			name:        "opposing captain only sees their own lineup",
+			deadline:    &future,
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+			identity:    guestCaptain,
+			want:        []string{"guest"},
+		},
+		{
+			name:        "captain does not see a submitted lineup when their own is locked",
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusLocked,
+			identity:    guestCaptain,
+			want:        []string{"guest"},
+		},
+		{
+			name:        "captain sees their own lineup",
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+			identity:    homeCaptain,
+			want:        []string{"home"},
+		},
+		{
+			name:        "lineup managers see both",
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+			identity:    moderator,
+			want:        []string{"home", "guest"},
+		},
+		{
+			name:        "both locked are public",
+			homeStatus:  models.LineupStatusLocked,
+			guestStatus: models.LineupStatusLocked,
+			want:        []string{"home", "guest"},
+		},
+		{
+			name:        "past the deadline both are public",
+			deadline:    &past,
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+			want:        []string{"home", "guest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &repository.Repository{
+				Fixture: &lineupFixtureRepo{fixture: &models.Fixture{
+					ID:             "fixture",
+					SeasonID:       "season",
+					HomeTeamID:     "home",
+					GuestTeamID:    "guest",
+					LineupDeadline: tt.deadline,
+				}},
+				Lineup: &lineupRepo{lineups: []models.Lineup{
+					{FixtureID: "fixture", TeamID: "home", Status: tt.homeStatus},
+					{FixtureID: "fixture", TeamID: "guest", Status: tt.guestStatus},
+				}},
+				Admin: &captainRepo{teams: map[string]string{"home-captain": "home", "guest-captain": "guest"}},
+			}
+			lineups := NewLineupService(store, config.LeagueConfig{})
+
+			ctx := context.Background()
+			if tt.identity != nil {
+				ctx = auth.WithIdentity(ctx, tt.identity)
+			}
+
+			got, err := lineups.GetFixtureLineupsService(ctx, "season", "fixture")
+			if err != nil {
+				t.Fatalf("GetFixtureLineupsService: %v", err)
+			}
+
+			teams := make([]string, 0, len(got))
+			for _, lineup := range got {
+				teams = append(teams, lineup.TeamID)
+			}
+			if len(teams) != len(tt.want) {
+				t.Fatalf("lineups of %v, want %v", teams, tt.want)
+			}
+			for i := range teams {
+				if teams[i] != tt.want[i] {
+					t.Fatalf("lineups of %v, want %v", teams, tt.want)
+				}
+			}
+		})
+	}
+}
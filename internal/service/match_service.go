@@ -0,0 +1,897 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"backend-ping-pong-app/internal/config"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/handicap"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/rating"
+	"backend-ping-pong-app/internal/repository"
+)
+
+// Table tennis set rules: a set is won at 11 points with a 2 point margin
+const (
+	setWinningPoints = 11
+	setWinningMargin = 2
+)
+
+type MatchService interface {
+	GetMatchesByFixtureService(ctx context.Context, seasonID, fixtureID string) ([]models.Match, error)
+	GetMatchByIDService(ctx context.Context, seasonID, fixtureID, id string) (*models.Match, error)
+	RecordMatchService(ctx context.Context, seasonID string, match *models.Match, noShowPlayerIDs []string) (*models.Match, error)
+	RecalculateFixtureService(ctx context.Context, seasonID, fixtureID string) (*models.Fixture, error)
+	GetMatchSheetService(ctx context.Context, seasonID, fixtureID string) (*models.MatchSheet, error)
+}
+
+// matchService works on the whole repository because recording a rubber
+// also updates its fixture in the same transaction
+type matchService struct {
+	store  *repository.Repository
+	league config.LeagueConfig
+	rating rating.Calculator
+}
+
+func NewMatchService(store *repository.Repository, league config.LeagueConfig, calculator rating.Calculator) MatchService {
+	return &matchService{store: store, league: league, rating: calculator}
+}
+
+func (s *matchService) GetMatchesByFixtureService(ctx context.Context, seasonID, fixtureID string) ([]models.Match, error) {
+	if _, err := s.getFixture(ctx, seasonID, fixtureID); err != nil {
+		return nil, err
+	}
+
+	matches, err := s.store.Match.GetMatchesByFixtureRepo(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+
+	if matches == nil {
+		matches = []models.Match{}
+	}
+	return matches, nil
+}
+
+func (s *matchService) GetMatchByIDService(ctx context.Context, seasonID, fixtureID, id string) (*models.Match, error) {
+	if _, err := s.getFixture(ctx, seasonID, fixtureID); err != nil {
+		return nil, err
+	}
+
+	match, err := s.store.Match.GetMatchByIDRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if match == nil || match.FixtureID != fixtureID {
+		return nil, apperrors.MatchNotFound()
+	}
+
+	return match, nil
+}
+
+// RecordMatchService records the result of a rubber. noShowPlayerIDs lists
+// the players of the losing side of a walkover who did not show up without a
+// valid reason; each is penalized immediately with a PENALTY point log.
+func (s *matchService) RecordMatchService(ctx context.Context, seasonID string, match *models.Match, noShowPlayerIDs []string) (*models.Match, error) {
+	if match.MatchOrder < 1 || match.MatchOrder > s.league.RubbersPerFixture {
+		return nil, apperrors.InvalidInput(fmt.Sprintf("match_order phải từ 1 đến %d", s.league.RubbersPerFixture))
+	}
+
+	if match.ResultType == "" {
+		match.ResultType = models.ResultTypeNormal
+	}
+	if !models.IsValidResultType(match.ResultType) {
+		return nil, apperrors.InvalidInput("result_type phải là NORMAL, WALKOVER, RETIRED hoặc DISQUALIFIED")
+	}
+	if len(noShowPlayerIDs) > 0 && match.ResultType != models.ResultTypeWalkover {
+		return nil, apperrors.InvalidInput("no_show_player_ids chỉ dùng cho kết quả WALKOVER")
+	}
+
+	if err := validateMatchPlayers(match); err != nil {
+		return nil, err
+	}
+
+	homeWins, guestWins, err := validateResultSets(match, s.league.BestOf)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *models.Match
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		// Lock the fixture so concurrent rubbers are aggregated one at a time
+		fixture, err := tx.Fixture.LockFixtureRepo(ctx, match.FixtureID)
+		if err != nil {
+			return err
+		}
+		if fixture == nil || fixture.SeasonID != seasonID {
+			return apperrors.FixtureNotFound()
+		}
+
+		if fixture.Status == models.FixtureStatusCompleted || fixture.Status == models.FixtureStatusCancelled {
+			return apperrors.FixtureNotActive()
+		}
+
+		if err := ensureRoundOpen(ctx, tx, seasonID, fixture.Round); err != nil {
+			return err
+		}
+
+		if err := validateMatchTeams(ctx, tx, fixture, match); err != nil {
+			return err
+		}
+
+		existing, err := tx.Match.GetMatchByOrderRepo(ctx, match.FixtureID, match.MatchOrder)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if existing.Played() {
+				return apperrors.MatchAlreadyRecorded()
+			}
+			// The rubber was pre-filled from the locked lineups
+			if !sameMatchPlayers(existing, match) {
+				return apperrors.InvalidPlayers().WithDetails(map[string]interface{}{
+					"match_order":      match.MatchOrder,
+					"home_player_ids":  existing.HomePlayerIDs(),
+					"guest_player_ids": existing.GuestPlayerIDs(),
+				})
+			}
+			match.ID = existing.ID
+		}
+
+		homeWon, err := resultWinner(fixture, match, homeWins, guestWins)
+		if err != nil {
+			return err
+		}
+		losers := match.GuestPlayerIDs()
+		if homeWon {
+			match.WinnerTeamID = &fixture.HomeTeamID
+		} else {
+			match.WinnerTeamID = &fixture.GuestTeamID
+			losers = match.HomePlayerIDs()
+		}
+
+		for _, playerID := range noShowPlayerIDs {
+			if !contains(losers, playerID) {
+				return apperrors.InvalidPlayers().WithDetails(map[string]interface{}{
+					"no_show_player_id": playerID,
+					"losing_player_ids": losers,
+				})
+			}
+		}
+
+		settings, err := loadSeasonSettings(ctx, tx.Settings, seasonID)
+		if err != nil {
+			return err
+		}
+
+		change, err := s.rateMatch(ctx, tx, seasonID, match, homeWon, settings.HandicapFormula())
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			created, err = tx.Match.UpdateMatchResultRepo(ctx, match)
+		} else {
+			created, err = tx.Match.CreateMatchRepo(ctx, match)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := change.log(ctx, tx, created); err != nil {
+			return err
+		}
+
+		if err := penalizeNoShows(ctx, tx, seasonID, created, noShowPlayerIDs, settings.NoShowPenaltyPoints); err != nil {
+			return err
+		}
+
+		_, err = syncFixtureScore(ctx, tx, fixture, s.league)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *matchService) RecalculateFixtureService(ctx context.Context, seasonID, fixtureID string) (*models.Fixture, error) {
+	var fixture *models.Fixture
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		locked, err := tx.Fixture.LockFixtureRepo(ctx, fixtureID)
+		if err != nil {
+			return err
+		}
+		if locked == nil || locked.SeasonID != seasonID {
+			return apperrors.FixtureNotFound()
+		}
+
+		if locked.Status == models.FixtureStatusCancelled {
+			return apperrors.FixtureNotActive()
+		}
+
+		if err := ensureRoundOpen(ctx, tx, seasonID, locked.Round); err != nil {
+			return err
+		}
+
+		fixture, err = syncFixtureScore(ctx, tx, locked, s.league)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fixture, nil
+}
+
+// GetMatchSheetService lists the rubbers of a fixture with the players' names,
+// the ranks they played at and their handicap. Rubbers without a row yet are
+// filled in from the locked lineups with the handicap of the players'
+// current ranks, so umpires know the starting score before play.
+func (s *matchService) GetMatchSheetService(ctx context.Context, seasonID, fixtureID string) (*models.MatchSheet, error) {
+	fixture, err := s.getFixture(ctx, seasonID, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &models.MatchSheet{Fixture: *fixture, Rubbers: []models.MatchSheetRubber{}}
+	for _, side := range []struct {
+		teamID string
+		name   *string
+	}{
+		{fixture.HomeTeamID, &sheet.HomeTeamName},
+		{fixture.GuestTeamID, &sheet.GuestTeamName},
+	} {
+		team, err := s.store.Team.GetTeamByIDRepo(ctx, side.teamID)
+		if err != nil {
+			return nil, err
+		}
+		if team != nil {
+			*side.name = team.Name
+		}
+	}
+
+	roster, err := s.store.PlayerSeason.GetRosterRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	players := make(map[string]models.RosterEntry, len(roster))
+	for _, entry := range roster {
+		players[entry.PlayerID] = entry
+	}
+
+	matches, err := s.store.Match.GetMatchesByFixtureRepo(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	upcoming, err := s.upcomingMatches(ctx, fixture, matches)
+	if err != nil {
+		return nil, err
+	}
+	matches = append(matches, upcoming...)
+	sort.Slice(matches, func(i, j int) bool { return matches[i].MatchOrder < matches[j].MatchOrder })
+
+	for _, match := range matches {
+		// Ranks come from the snapshot so the sheet shows the rank played at
+		rankSnapshot := make(map[string]string)
+		if match.RankSnapshot != nil {
+			if err := json.Unmarshal([]byte(*match.RankSnapshot), &rankSnapshot); err != nil {
+				return nil, err
+			}
+		}
+
+		sheetPlayers := func(playerIDs []string) []models.MatchSheetPlayer {
+			side := make([]models.MatchSheetPlayer, 0, len(playerIDs))
+			for _, playerID := range playerIDs {
+				rankID, ok := rankSnapshot[playerID]
+				if !ok {
+					rankID = players[playerID].RankID
+				}
+				side = append(side, models.MatchSheetPlayer{
+					PlayerID:   playerID,
+					PlayerName: players[playerID].PlayerName,
+					RankID:     rankID,
+				})
+			}
+			return side
+		}
+
+		rubber := models.MatchSheetRubber{
+			MatchID:      match.ID,
+			MatchOrder:   match.MatchOrder,
+			MatchType:    match.MatchType,
+			HomePlayers:  sheetPlayers(match.HomePlayerIDs()),
+			GuestPlayers: sheetPlayers(match.GuestPlayerIDs()),
+			HomeSets:     match.HomeSets,
+			GuestSets:    match.GuestSets,
+			WinnerTeamID: match.WinnerTeamID,
+			ResultType:   match.ResultType,
+		}
+		if rubber.HomeSets == nil {
+			rubber.HomeSets, rubber.GuestSets = []int64{}, []int64{}
+		}
+		if match.HandicapSnapshot != nil {
+			rubber.Handicap = &handicap.Result{}
+			if err := json.Unmarshal([]byte(*match.HandicapSnapshot), rubber.Handicap); err != nil {
+				return nil, err
+			}
+		}
+
+		sheet.Rubbers = append(sheet.Rubbers, rubber)
+	}
+
+	return sheet, nil
+}
+
+// upcomingMatches builds the rubbers of the fixture missing from recorded
+// from the lineups of both teams, without saving them. It returns none until
+// both lineups are locked, so the sheet never shows a pairing that can still
+// change or a lineup the other team has not committed to.
+func (s *matchService) upcomingMatches(ctx context.Context, fixture *models.Fixture, recorded []models.Match) ([]models.Match, error) {
+	lineups, err := s.store.Lineup.GetLineupsByFixtureRepo(ctx, fixture.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var home, guest *models.Lineup
+	for i := range lineups {
+		if lineups[i].Status != models.LineupStatusLocked {
+			continue
+		}
+		switch lineups[i].TeamID {
+		case fixture.HomeTeamID:
+			home = &lineups[i]
+		case fixture.GuestTeamID:
+			guest = &lineups[i]
+		}
+	}
+	if home == nil || guest == nil {
+		return nil, nil
+	}
+
+	settings, err := loadSeasonSettings(ctx, s.store.Settings, fixture.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := s.store.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	played := make(map[int]bool, len(recorded))
+	for _, match := range recorded {
+		played[match.MatchOrder] = true
+	}
+	guestEntries := make(map[int]models.LineupEntry, len(guest.Entries))
+	for _, entry := range guest.Entries {
+		guestEntries[entry.MatchOrder] = entry
+	}
+
+	var matches []models.Match
+	for _, homeEntry := range home.Entries {
+		guestEntry, ok := guestEntries[homeEntry.MatchOrder]
+		if !ok || played[homeEntry.MatchOrder] {
+			continue
+		}
+
+		match, err := prefillMatch(ctx, s.store, fixture, homeEntry, guestEntry, settings.HandicapFormula(), ranks)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, *match)
+	}
+	return matches, nil
+}
+
+func (s *matchService) getFixture(ctx context.Context, seasonID, fixtureID string) (*models.Fixture, error) {
+	fixture, err := s.store.Fixture.GetFixtureByIDRepo(ctx, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixture == nil || fixture.SeasonID != seasonID {
+		return nil, apperrors.FixtureNotFound()
+	}
+
+	return fixture, nil
+}
+
+// ratingChange holds the rating deltas of a rubber until they are logged
+type ratingChange struct {
+	playerSeasonIDs map[string]string // player id -> player season id
+	deltas          map[string]float64
+}
+
+// rateMatch computes the rating delta of every player of the rubber from their
+// current season points and fills the rank, handicap and point snapshots of the
+// match. Deltas are applied to accumulated points when the round is finalized.
+// Unrated results (walkovers) get no delta. A nil formula means the season
+// plays without handicap.
+func (s *matchService) rateMatch(ctx context.Context, tx *repository.Repository, seasonID string, match *models.Match, homeWon bool, formula *handicap.Formula) (*ratingChange, error) {
+	ranks, err := tx.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kFactors := make(map[string]float64, len(ranks))
+	for _, rank := range ranks {
+		kFactors[rank.ID] = float64(rank.KFactor)
+	}
+
+	change := &ratingChange{playerSeasonIDs: make(map[string]string)}
+	rankSnapshot := make(map[string]string)
+	pointBefore := make(map[string]float64)
+
+	participants := func(playerIDs []string) ([]rating.Participant, error) {
+		side := make([]rating.Participant, 0, len(playerIDs))
+		for _, playerID := range playerIDs {
+			ps, err := tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, seasonID, playerID)
+			if err != nil {
+				return nil, err
+			}
+			if ps == nil {
+				return nil, apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"player_id": playerID})
+			}
+
+			change.playerSeasonIDs[playerID] = ps.ID
+			rankSnapshot[playerID] = ps.RankID
+			pointBefore[playerID] = ps.AccumulatedPoints
+			side = append(side, rating.Participant{
+				PlayerID: playerID,
+				Rating:   ps.AccumulatedPoints,
+				KFactor:  kFactors[ps.RankID],
+			})
+		}
+		return side, nil
+	}
+
+	home, err := participants(match.HomePlayerIDs())
+	if err != nil {
+		return nil, err
+	}
+	guest, err := participants(match.GuestPlayerIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	if match.HandicapSnapshot, err = handicapSnapshot(formula, ranks, rankSnapshot, match); err != nil {
+		return nil, err
+	}
+
+	change.deltas = make(map[string]float64)
+	if match.Rated() {
+		change.deltas = s.rating.Deltas(rating.Outcome{Home: home, Guest: guest, HomeWon: homeWon})
+	}
+
+	pointAfter := make(map[string]float64, len(pointBefore))
+	for playerID, before := range pointBefore {
+		pointAfter[playerID] = before + change.deltas[playerID]
+	}
+
+	if match.RankSnapshot, err = jsonString(rankSnapshot); err != nil {
+		return nil, err
+	}
+	if match.PointBefore, err = jsonString(pointBefore); err != nil {
+		return nil, err
+	}
+	if match.PointAfter, err = jsonString(pointAfter); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// log writes one MATCH point log per player referencing the recorded rubber;
+// unrated results write none
+func (c *ratingChange) log(ctx context.Context, tx *repository.Repository, match *models.Match) error {
+	if !match.Rated() {
+		return nil
+	}
+
+	reason := fmt.Sprintf("Kết quả trận %d", match.MatchOrder)
+
+	for _, playerID := range append(match.HomePlayerIDs(), match.GuestPlayerIDs()...) {
+		_, err := tx.PointLog.CreatePointLogRepo(ctx, &models.PointLog{
+			PlayerSeasonID: c.playerSeasonIDs[playerID],
+			DeltaPoints:    c.deltas[playerID],
+			Reason:         &reason,
+			Source:         models.PointSourceMatch,
+			RefID:          &match.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// penalizeNoShows deducts the season's no-show penalty from every listed
+// player straight away, never taking their points below zero
+func penalizeNoShows(ctx context.Context, tx *repository.Repository, seasonID string, match *models.Match, playerIDs []string, penalty int) error {
+	if penalty == 0 {
+		return nil
+	}
+
+	reason := fmt.Sprintf("Bỏ trận %d không có lý do", match.MatchOrder)
+	for _, playerID := range playerIDs {
+		ps, err := tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, seasonID, playerID)
+		if err != nil {
+			return err
+		}
+		if ps == nil {
+			return apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"player_id": playerID})
+		}
+
+		delta := -math.Min(float64(penalty), ps.AccumulatedPoints)
+		if delta == 0 {
+			continue
+		}
+
+		if _, err := tx.PlayerSeason.AddAccumulatedPointsRepo(ctx, ps.ID, delta); err != nil {
+			return err
+		}
+		_, err = tx.PointLog.CreatePointLogRepo(ctx, &models.PointLog{
+			PlayerSeasonID: ps.ID,
+			DeltaPoints:    delta,
+			Reason:         &reason,
+			Source:         models.PointSourcePenalty,
+			RefID:          &match.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handicapSnapshot computes the handicap of a rubber from the ranks of its
+// players (player id -> rank id); it returns nil when formula is nil and
+// RANK_NOT_FOUND for a player without a known rank
+func handicapSnapshot(formula *handicap.Formula, ranks []models.Rank, playerRanks map[string]string, match *models.Match) (*string, error) {
+	if formula == nil {
+		return nil, nil
+	}
+
+	standardScores := make(map[string]int, len(ranks))
+	for _, rank := range ranks {
+		standardScores[rank.ID] = rank.StandardScore
+	}
+
+	sideScores := func(playerIDs []string) ([]int, error) {
+		scores := make([]int, 0, len(playerIDs))
+		for _, playerID := range playerIDs {
+			score, ok := standardScores[playerRanks[playerID]]
+			if !ok {
+				return nil, apperrors.RankNotFound().WithDetails(map[string]string{"player_id": playerID})
+			}
+			scores = append(scores, score)
+		}
+		return scores, nil
+	}
+
+	home, err := sideScores(match.HomePlayerIDs())
+	if err != nil {
+		return nil, err
+	}
+	guest, err := sideScores(match.GuestPlayerIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := formula.Calculate(home, guest)
+	if err != nil {
+		return nil, err
+	}
+	return jsonString(result)
+}
+
+// sameMatchPlayers reports whether two rubbers have the same type and the
+// same players on each side, in any order within a doubles pair
+func sameMatchPlayers(a, b *models.Match) bool {
+	if a.MatchType != b.MatchType {
+		return false
+	}
+
+	sameSide := func(x, y []string) bool {
+		if len(x) != len(y) {
+			return false
+		}
+		for _, id := range x {
+			found := false
+			for _, other := range y {
+				if id == other {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	return sameSide(a.HomePlayerIDs(), b.HomePlayerIDs()) && sameSide(a.GuestPlayerIDs(), b.GuestPlayerIDs())
+}
+
+func jsonString(v interface{}) (*string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	str := string(data)
+	return &str, nil
+}
+
+// syncFixtureScore recomputes a fixture's score and status from its recorded
+// rubbers. The fixture moves to ONGOING on the first rubber and to COMPLETED
+// once a side reaches RubbersToWin or every rubber has been played. Rubbers
+// pre-filled from the lineups only count once their result is recorded.
+func syncFixtureScore(ctx context.Context, tx *repository.Repository, fixture *models.Fixture, league config.LeagueConfig) (*models.Fixture, error) {
+	matches, err := tx.Match.GetMatchesByFixtureRepo(ctx, fixture.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasCompleted := fixture.Status == models.FixtureStatusCompleted
+
+	fixture.HomeScore, fixture.GuestScore = 0, 0
+	played := 0
+	for _, match := range matches {
+		if !match.Played() {
+			continue
+		}
+		played++
+		switch *match.WinnerTeamID {
+		case fixture.HomeTeamID:
+			fixture.HomeScore++
+		case fixture.GuestTeamID:
+			fixture.GuestScore++
+		}
+	}
+
+	switch {
+	case fixture.HomeScore >= league.RubbersToWin,
+		fixture.GuestScore >= league.RubbersToWin,
+		played >= league.RubbersPerFixture:
+		fixture.Status = models.FixtureStatusCompleted
+	case played > 0:
+		fixture.Status = models.FixtureStatusOngoing
+	default:
+		fixture.Status = models.FixtureStatusScheduled
+	}
+
+	updated, err := tx.Fixture.UpdateFixtureRepo(ctx, fixture)
+	if err != nil {
+		return nil, err
+	}
+
+	// Team standings only count completed fixtures
+	if wasCompleted || updated.Status == models.FixtureStatusCompleted {
+		if _, err := recomputeTeamStandings(ctx, tx, updated.SeasonID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !wasCompleted && updated.Status == models.FixtureStatusCompleted {
+		if err := advanceBracket(ctx, tx, updated); err != nil {
+			return nil, err
+		}
+	}
+
+	return updated, nil
+}
+
+// validateMatchPlayers checks the player slots against the match type and
+// rejects a player appearing twice in the same rubber
+func validateMatchPlayers(match *models.Match) error {
+	switch match.MatchType {
+	case models.MatchTypeSingle:
+		if match.HomePlayer2ID != nil || match.GuestPlayer2ID != nil {
+			return apperrors.InvalidPlayers()
+		}
+	case models.MatchTypeDouble:
+		if match.HomePlayer2ID == nil || match.GuestPlayer2ID == nil {
+			return apperrors.InvalidPlayers()
+		}
+	default:
+		return apperrors.InvalidInput("match_type phải là SINGLE hoặc DOUBLE")
+	}
+
+	seen := make(map[string]bool)
+	for _, playerID := range append(match.HomePlayerIDs(), match.GuestPlayerIDs()...) {
+		if playerID == "" || seen[playerID] {
+			return apperrors.InvalidPlayers()
+		}
+		seen[playerID] = true
+	}
+
+	return nil
+}
+
+// validateMatchTeams checks that every player belonged to their side's team in
+// the round of the fixture, so transferred players only count for one team per round
+func validateMatchTeams(ctx context.Context, tx *repository.Repository, fixture *models.Fixture, match *models.Match) error {
+	sides := []struct {
+		teamID    string
+		playerIDs []string
+	}{
+		{fixture.HomeTeamID, match.HomePlayerIDs()},
+		{fixture.GuestTeamID, match.GuestPlayerIDs()},
+	}
+
+	for _, side := range sides {
+		for _, playerID := range side.playerIDs {
+			teamID, err := playerTeamAtRound(ctx, tx, fixture.SeasonID, playerID, fixture.Round)
+			if err != nil {
+				return err
+			}
+			if teamID != side.teamID {
+				return apperrors.InvalidPlayers().WithDetails(map[string]interface{}{
+					"player_id": playerID,
+					"team_id":   side.teamID,
+					"round":     fixture.Round,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateResultSets checks the sets against the result type and returns the
+// number of finished sets won by each side. A walkover has no sets; a
+// retirement or disqualification may stop before the rubber is decided.
+func validateResultSets(match *models.Match, bestOf int) (int, int, error) {
+	switch match.ResultType {
+	case models.ResultTypeWalkover:
+		if len(match.HomeSets) > 0 || len(match.GuestSets) > 0 {
+			return 0, 0, apperrors.MatchInvalidSets("Trận xử thắng không có tỉ số set")
+		}
+		return 0, 0, nil
+	case models.ResultTypeRetired, models.ResultTypeDisqualified:
+		return validateUnfinishedSetScores(match.HomeSets, match.GuestSets, bestOf)
+	default:
+		return validateSetScores(match.HomeSets, match.GuestSets, bestOf)
+	}
+}
+
+// resultWinner reports whether the home side won the rubber: from the sets of
+// a NORMAL result, otherwise from the winner_team_id given with the result
+func resultWinner(fixture *models.Fixture, match *models.Match, homeWins, guestWins int) (bool, error) {
+	if match.ResultType == models.ResultTypeNormal {
+		homeWon := homeWins > guestWins
+		if match.WinnerTeamID != nil && (*match.WinnerTeamID == fixture.HomeTeamID) != homeWon {
+			return false, apperrors.InvalidInput("winner_team_id không khớp với tỉ số set")
+		}
+		return homeWon, nil
+	}
+
+	if match.WinnerTeamID == nil {
+		return false, apperrors.InvalidInput(fmt.Sprintf("winner_team_id là bắt buộc với kết quả %s", match.ResultType))
+	}
+	switch *match.WinnerTeamID {
+	case fixture.HomeTeamID:
+		return true, nil
+	case fixture.GuestTeamID:
+		return false, nil
+	}
+	return false, apperrors.InvalidTeamMatch()
+}
+
+// validateSetScores checks every set against the 11 point / win by 2 rule and
+// that the rubber stops as soon as one side has won a best-of-N majority.
+// It returns the number of sets won by each side.
+func validateSetScores(homeSets, guestSets []int64, bestOf int) (int, int, error) {
+	if len(homeSets) == 0 || len(homeSets) != len(guestSets) {
+		return 0, 0, apperrors.MatchInvalidSets("Số set của hai bên không khớp")
+	}
+
+	setsToWin := bestOf/2 + 1
+	if len(homeSets) > bestOf {
+		return 0, 0, apperrors.MatchInvalidSets(fmt.Sprintf("Trận đấu chỉ có tối đa %d set", bestOf))
+	}
+
+	homeWins, guestWins := 0, 0
+	for i := range homeSets {
+		if homeWins == setsToWin || guestWins == setsToWin {
+			return 0, 0, apperrors.MatchInvalidSets("Có set được ghi sau khi trận đấu đã kết thúc")
+		}
+
+		home, guest := homeSets[i], guestSets[i]
+		if !isValidSetScore(home, guest) {
+			return 0, 0, apperrors.MatchInvalidSets(fmt.Sprintf("Tỉ số set %d (%d-%d) không hợp lệ", i+1, home, guest)).
+				WithDetails(map[string]int{"set": i + 1})
+		}
+
+		if home > guest {
+			homeWins++
+		} else {
+			guestWins++
+		}
+	}
+
+	if homeWins != setsToWin && guestWins != setsToWin {
+		return 0, 0, apperrors.MatchInvalidSets(fmt.Sprintf("Trận đấu chưa kết thúc, cần thắng %d set", setsToWin))
+	}
+
+	return homeWins, guestWins, nil
+}
+
+// validateUnfinishedSetScores checks the sets of a rubber stopped by a
+// retirement or disqualification: every set but the last must be finished, the
+// last may still be in progress and neither side may have won the rubber yet.
+// It returns the number of finished sets won by each side.
+func validateUnfinishedSetScores(homeSets, guestSets []int64, bestOf int) (int, int, error) {
+	if len(homeSets) != len(guestSets) {
+		return 0, 0, apperrors.MatchInvalidSets("Số set của hai bên không khớp")
+	}
+	if len(homeSets) > bestOf {
+		return 0, 0, apperrors.MatchInvalidSets(fmt.Sprintf("Trận đấu chỉ có tối đa %d set", bestOf))
+	}
+
+	setsToWin := bestOf/2 + 1
+	homeWins, guestWins := 0, 0
+	for i := range homeSets {
+		home, guest := homeSets[i], guestSets[i]
+		if !isValidSetScore(home, guest) {
+			if i == len(homeSets)-1 && isInProgressSetScore(home, guest) {
+				continue
+			}
+			return 0, 0, apperrors.MatchInvalidSets(fmt.Sprintf("Tỉ số set %d (%d-%d) không hợp lệ", i+1, home, guest)).
+				WithDetails(map[string]int{"set": i + 1})
+		}
+
+		if home > guest {
+			homeWins++
+		} else {
+			guestWins++
+		}
+		if homeWins == setsToWin || guestWins == setsToWin {
+			return 0, 0, apperrors.MatchInvalidSets("Trận đấu đã phân thắng bại, hãy ghi nhận kết quả NORMAL")
+		}
+	}
+
+	return homeWins, guestWins, nil
+}
+
+// isInProgressSetScore reports whether a set could still be in progress at
+// this score: nobody has reached 11 with a 2 point lead
+func isInProgressSetScore(home, guest int64) bool {
+	if home < 0 || guest < 0 {
+		return false
+	}
+
+	diff := home - guest
+	if diff < 0 {
+		diff = -diff
+	}
+	return (home < setWinningPoints && guest < setWinningPoints) || diff < setWinningMargin
+}
+
+// isValidSetScore reports whether a finished set score is possible: the winner
+// reaches 11, and once the loser passes 9 the margin must be exactly 2
+func isValidSetScore(home, guest int64) bool {
+	if home < 0 || guest < 0 {
+		return false
+	}
+
+	winner, loser := home, guest
+	if guest > home {
+		winner, loser = guest, home
+	}
+
+	if winner < setWinningPoints {
+		return false
+	}
+	if loser <= setWinningPoints-setWinningMargin {
+		return winner == setWinningPoints
+	}
+	return winner-loser == setWinningMargin
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type matchSettingsRepo struct {
+	repository.SeasonSettingsRepository
+}
+
+func (r *matchSettingsRepo) GetSeasonSettingsRepo(ctx context.Context, seasonID string) (*models.SeasonSettings, error) {
+	settings := models.DefaultSeasonSettings(seasonID)
+	settings.HandicapEnabled = true
+	return settings, nil
+}
+
+type matchRankRepo struct {
+	repository.RankRepository
+}
+
+func (r *matchRankRepo) GetAllRanksRepo(ctx context.Context) ([]models.Rank, error) {
+	return []models.Rank{{ID: "strong", StandardScore: 120}, {ID: "weak", StandardScore: 90}}, nil
+}
+
+// matchPlayerSeasonRepo holds the rank of every player by id
+type matchPlayerSeasonRepo struct {
+	repository.PlayerSeasonRepository
+	ranks map[string]string
+}
+
+func (r *matchPlayerSeasonRepo) GetPlayerSeasonByPlayerRepo(ctx context.Context, seasonID, playerID string) (*models.PlayerSeason, error) {
+	return &models.PlayerSeason{SeasonID: seasonID, PlayerID: playerID, RankID: r.ranks[playerID]}, nil
+}
+
+func TestUpcomingMatchesNeedLockedLineups(t *testing.T) {
+	fixture := &models.Fixture{ID: "fixture", SeasonID: "season", HomeTeamID: "home", GuestTeamID: "guest"}
+	lineup := func(teamID, status, playerID string) models.Lineup {
+		return models.Lineup{
+			FixtureID: fixture.ID,
+			TeamID:    teamID,
+			Status:    status,
+			Entries: []models.LineupEntry{
+				{MatchOrder: 1, MatchType: models.MatchTypeSingle, Player1ID: playerID},
+				{MatchOrder: 2, MatchType: models.MatchTypeSingle, Player1ID: playerID},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		homeStatus  string
+		guestStatus string
+		recorded    []models.Match
+		wantOrders  []int
+	}{
+		{
+			name:        "both submitted",
+			homeStatus:  models.LineupStatusSubmitted,
+			guestStatus: models.LineupStatusSubmitted,
+		},
+		{
+			name:        "one locked",
+			homeStatus:  models.LineupStatusLocked,
+			guestStatus: models.LineupStatusSubmitted,
+		},
+		{
+			name:        "both locked",
+			homeStatus:  models.LineupStatusLocked,
+			guestStatus: models.LineupStatusLocked,
+			wantOrders:  []int{1, 2},
+		},
+		{
+			name:        "recorded rubbers are skipped",
+			homeStatus:  models.LineupStatusLocked,
+			guestStatus: models.LineupStatusLocked,
+			recorded:    []models.Match{{MatchOrder: 1}},
+			wantOrders:  []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &repository.Repository{
+				Lineup: &lineupRepo{lineups: []models.Lineup{
+					lineup("home", tt.homeStatus, "home-player"),
+					lineup("guest", tt.guestStatus, "guest-player"),
+				}},
+				Settings:     &matchSettingsRepo{},
+				Rank:         &matchRankRepo{},
+				PlayerSeason: &matchPlayerSeasonRepo{ranks: map[string]string{"home-player": "weak", "guest-player": "strong"}},
+			}
+			matches := &matchService{store: store}
+
+			got, err := matches.upcomingMatches(context.Background(), fixture, tt.recorded)
+			if err != nil {
+				t.Fatalf("upcomingMatches: %v", err)
+			}
+			if len(got) != len(tt.wantOrders) {
+				t.Fatalf("upcomingMatches = %d rubbers, want %d", len(got), len(tt.wantOrders))
+			}
+			for i, match := range got {
+				if match.MatchOrder != tt.wantOrders[i] {
+					t.Errorf("rubber %d order = %d, want %d", i, match.MatchOrder, tt.wantOrders[i])
+				}
+				if match.HandicapSnapshot == nil {
+					t.Errorf("rubber %d has no handicap snapshot", match.MatchOrder)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+	"backend-ping-pong-app/internal/utils"
+)
+
+type PlayerSeasonService interface {
+	GetSeasonRosterService(ctx context.Context, seasonID string) ([]models.RosterEntry, error)
+	RegisterPlayerService(ctx context.Context, ps *models.PlayerSeason) (*models.PlayerSeason, error)
+	UpdatePlayerSeasonStatusService(ctx context.Context, seasonID, id, status string) (*models.PlayerSeason, error)
+	ReorderRosterService(ctx context.Context, seasonID string, playerSeasonIDs []string) ([]models.RosterEntry, error)
+	AdjustPointsService(ctx context.Context, id string, delta float64, reason, source string) (*models.PointAdjustment, error)
+	GetPointHistoryService(ctx context.Context, id string) (*models.PointHistory, error)
+}
+
+type playerSeasonService struct {
+	store *repository.Repository
+}
+
+func NewPlayerSeasonService(store *repository.Repository) PlayerSeasonService {
+	return &playerSeasonService{store: store}
+}
+
+func (s *playerSeasonService) GetSeasonRosterService(ctx context.Context, seasonID string) ([]models.RosterEntry, error) {
+	roster, err := s.store.PlayerSeason.GetRosterRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range roster {
+		if roster[i].AvatarURL != nil {
+			url := utils.BuildCDNURL(*roster[i].AvatarURL)
+			roster[i].AvatarURL = &url
+		}
+	}
+	if roster == nil {
+		roster = []models.RosterEntry{}
+	}
+	return roster, nil
+}
+
+// RegisterPlayerService adds a player to a season with a starting rank. When
+// no starting points are given the player starts at the bottom of the rank's
+// score band so the rank state machine does not relegate them straight away.
+func (s *playerSeasonService) RegisterPlayerService(ctx context.Context, ps *models.PlayerSeason) (*models.PlayerSeason, error) {
+	season, err := s.store.Season.GetSeasonByID(ctx, ps.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	player, err := s.store.Player.GetByID(ctx, ps.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, apperrors.PlayerNotFound()
+	}
+
+	team, err := s.store.Team.GetTeamByIDRepo(ctx, ps.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil || team.SeasonID != ps.SeasonID {
+		return nil, apperrors.TeamNotFound()
+	}
+
+	rank, err := s.store.Rank.GetRankByIDRepo(ctx, ps.RankID)
+	if err != nil {
+		return nil, err
+	}
+	if rank == nil {
+		return nil, apperrors.RankNotFound()
+	}
+
+	existing, err := s.store.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, ps.SeasonID, ps.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.PlayerAlreadyInSeason()
+	}
+
+	if ps.AccumulatedPoints < 0 {
+		return nil, apperrors.NegativePointsResult()
+	}
+	if ps.AccumulatedPoints == 0 && rank.MinScore != nil {
+		ps.AccumulatedPoints = float64(*rank.MinScore)
+	}
+	ps.Status = models.PlayerSeasonStatusActive
+
+	var created *models.PlayerSeason
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		created, err = tx.PlayerSeason.CreatePlayerSeasonRepo(ctx, ps)
+		if repository.IsUniqueViolation(err) {
+			return apperrors.PlayerAlreadyInSeason()
+		}
+		if err != nil {
+			return err
+		}
+
+		// The player counts for the team from the first round not yet finalized
+		latest, err := tx.Round.GetLatestFinalizedRoundRepo(ctx, ps.SeasonID)
+		if err != nil {
+			return err
+		}
+		return tx.Team.CreateTeamMemberRepo(ctx, ps.SeasonID, &models.TeamMember{
+			TeamID:       ps.TeamID,
+			PlayerID:     ps.PlayerID,
+			JoinedRound:  latest + 1,
+			TransferType: models.TransferTypeInitial,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *playerSeasonService) UpdatePlayerSeasonStatusService(ctx context.Context, seasonID, id, status string) (*models.PlayerSeason, error) {
+	if !models.IsValidPlayerSeasonStatus(status) {
+		return nil, apperrors.InvalidInput("status phải là ACTIVE, INACTIVE hoặc WITHDRAWN")
+	}
+
+	ps, err := s.getPlayerSeason(ctx, seasonID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.store.PlayerSeason.UpdatePlayerSeasonStatusRepo(ctx, id, status); err != nil {
+		return nil, err
+	}
+
+	ps.Status = status
+	return ps, nil
+}
+
+// ReorderRosterService sets display_order from the position of each id in
+// playerSeasonIDs; every player of the season must be listed exactly once
+func (s *playerSeasonService) ReorderRosterService(ctx context.Context, seasonID string, playerSeasonIDs []string) ([]models.RosterEntry, error) {
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		current, err := tx.PlayerSeason.GetPlayerSeasonsBySeasonRepo(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+
+		inSeason := make(map[string]bool, len(current))
+		for _, ps := range current {
+			inSeason[ps.ID] = true
+		}
+
+		if len(playerSeasonIDs) != len(current) {
+			return apperrors.InvalidInput("Danh sách phải gồm toàn bộ VĐV của mùa giải")
+		}
+
+		seen := make(map[string]bool, len(playerSeasonIDs))
+		for i, id := range playerSeasonIDs {
+			if !inSeason[id] || seen[id] {
+				return apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"player_season_id": id})
+			}
+			seen[id] = true
+
+			if err := tx.PlayerSeason.UpdateDisplayOrderRepo(ctx, id, i+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSeasonRosterService(ctx, seasonID)
+}
+
+// AdjustPointsService applies a manual change to a player's season points and
+// logs it with its reason in the same transaction. BONUS only adds points,
+// PENALTY only removes them and no adjustment may leave the player below zero.
+func (s *playerSeasonService) AdjustPointsService(ctx context.Context, id string, delta float64, reason, source string) (*models.PointAdjustment, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, apperrors.InvalidInput("reason là bắt buộc khi điều chỉnh điểm")
+	}
+
+	if source == "" {
+		source = models.PointSourceAdminAdjust
+	}
+	if !models.IsAdjustmentSource(source) {
+		return nil, apperrors.InvalidInput("source phải là ADMIN_ADJUST, PENALTY hoặc BONUS")
+	}
+
+	// Points are stored with 2 decimals
+	delta = math.Round(delta*100) / 100
+	if delta == 0 ||
+		(source == models.PointSourceBonus && delta < 0) ||
+		(source == models.PointSourcePenalty && delta > 0) {
+		return nil, apperrors.InvalidPointAdjustment().WithDetails(map[string]interface{}{
+			"delta_points": delta,
+			"source":       source,
+		})
+	}
+
+	var adjustment *models.PointAdjustment
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		ps, err := tx.PlayerSeason.LockPlayerSeasonRepo(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ps == nil {
+			return apperrors.PlayerSeasonNotFound()
+		}
+
+		if ps.AccumulatedPoints+delta < 0 {
+			return apperrors.NegativePointsResult().WithDetails(map[string]float64{
+				"accumulated_points": ps.AccumulatedPoints,
+				"delta_points":       delta,
+			})
+		}
+
+		total, err := tx.PlayerSeason.AddAccumulatedPointsRepo(ctx, ps.ID, delta)
+		if err != nil {
+			return err
+		}
+
+		log, err := tx.PointLog.CreatePointLogRepo(ctx, &models.PointLog{
+			PlayerSeasonID: ps.ID,
+			DeltaPoints:    delta,
+			Reason:         &reason,
+			Source:         source,
+		})
+		if err != nil {
+			return err
+		}
+
+		adjustment = &models.PointAdjustment{
+			Log:          *log,
+			PointsBefore: ps.AccumulatedPoints,
+			PointsAfter:  total,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return adjustment, nil
+}
+
+// GetPointHistoryService lists every point log of a player season with the
+// running total. The total is worked back from the current points so it also
+// covers the points the player was registered with.
+func (s *playerSeasonService) GetPointHistoryService(ctx context.Context, id string) (*models.PointHistory, error) {
+	ps, err := s.store.PlayerSeason.GetPlayerSeasonByIDRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ps == nil {
+		return nil, apperrors.PlayerSeasonNotFound()
+	}
+
+	entries, err := s.store.PointLog.GetPointHistoryRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.PointHistory{
+		PlayerSeasonID:    ps.ID,
+		AccumulatedPoints: ps.AccumulatedPoints,
+		Entries:           entries,
+	}
+	if history.Entries == nil {
+		history.Entries = []models.PointHistoryEntry{}
+	}
+
+	applied := 0.0
+	for _, entry := range entries {
+		if entry.Applied {
+			applied += entry.DeltaPoints
+		} else {
+			history.PendingPoints += entry.DeltaPoints
+		}
+	}
+	history.StartingPoints = ps.AccumulatedPoints - applied
+
+	running := history.StartingPoints
+	for i := range history.Entries {
+		if history.Entries[i].Applied {
+			running += history.Entries[i].DeltaPoints
+		}
+		history.Entries[i].RunningTotal = math.Round(running*100) / 100
+	}
+	history.StartingPoints = math.Round(history.StartingPoints*100) / 100
+	history.PendingPoints = math.Round(history.PendingPoints*100) / 100
+
+	return history, nil
+}
+
+func (s *playerSeasonService) getPlayerSeason(ctx context.Context, seasonID, id string) (*models.PlayerSeason, error) {
+	ps, err := s.store.PlayerSeason.GetPlayerSeasonByIDRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ps == nil || ps.SeasonID != seasonID {
+		return nil, apperrors.PlayerSeasonNotFound()
+	}
+	return ps, nil
+}
@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 
+	"backend-ping-pong-app/internal/auth"
+	apperrors "backend-ping-pong-app/internal/errors"
 	"backend-ping-pong-app/internal/models"
 	"backend-ping-pong-app/internal/repository"
 	"backend-ping-pong-app/internal/utils"
@@ -15,6 +18,9 @@ type PlayerService interface {
 	GetAllPlayerService(ctx context.Context) ([]models.PlayerListResponse, error)
 	SearchPlayerByNameService(ctx context.Context, name string) ([]models.PlayerListResponse, error)
 	CreatePlayerService(ctx context.Context, p *models.Player) (*models.Player, error)
+	GetPlayerProfileService(ctx context.Context, playerID string) (*models.Player, error)
+	UpdatePlayerProfileService(ctx context.Context, playerID string, update models.PlayerProfileUpdate) (*models.Player, error)
+	LinkPlayerAccountService(ctx context.Context, playerID string, firebaseUID *string) (*models.Player, error)
 }
 
 type playerService struct {
@@ -83,3 +89,91 @@ func (s *playerService) CreatePlayerService(ctx context.Context, p *models.Playe
 
 	return p, nil
 }
+
+// GetPlayerProfileService returns a player's full profile. Players may only
+// see their own; admins see every profile.
+func (s *playerService) GetPlayerProfileService(ctx context.Context, playerID string) (*models.Player, error) {
+	if err := authorizePlayerProfile(ctx, playerID, auth.PermissionRead); err != nil {
+		return nil, err
+	}
+
+	player, err := s.repo.GetByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, apperrors.PlayerNotFound()
+	}
+	return player, nil
+}
+
+// UpdatePlayerProfileService edits a player's profile. Players may only edit
+// their own; admins managing the league edit every profile.
+func (s *playerService) UpdatePlayerProfileService(ctx context.Context, playerID string, update models.PlayerProfileUpdate) (*models.Player, error) {
+	if err := authorizePlayerProfile(ctx, playerID, auth.PermissionManageLeague); err != nil {
+		return nil, err
+	}
+
+	if update.FullName != nil {
+		name := strings.TrimSpace(*update.FullName)
+		if name == "" {
+			return nil, apperrors.InvalidInput("Tên VĐV không được để trống")
+		}
+		update.FullName = &name
+	}
+
+	player, err := s.repo.UpdatePlayerProfileRepo(ctx, playerID, update)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, apperrors.PlayerNotFound()
+	}
+	return player, nil
+}
+
+// LinkPlayerAccountService links a player to a mobile app account by Firebase
+// UID, or unlinks it when firebaseUID is nil
+func (s *playerService) LinkPlayerAccountService(ctx context.Context, playerID string, firebaseUID *string) (*models.Player, error) {
+	if firebaseUID != nil {
+		uid := strings.TrimSpace(*firebaseUID)
+		if uid == "" {
+			return nil, apperrors.InvalidInput("firebase_uid không được để trống")
+		}
+
+		linked, err := s.repo.GetPlayerByFirebaseUIDRepo(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+		if linked != nil && linked.ID != playerID {
+			return nil, apperrors.PlayerAccountLinked().WithDetails(map[string]interface{}{"player_id": linked.ID})
+		}
+		firebaseUID = &uid
+	}
+
+	found, err := s.repo.LinkFirebaseUIDRepo(ctx, playerID, firebaseUID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, apperrors.PlayerNotFound()
+	}
+	return s.repo.GetByID(ctx, playerID)
+}
+
+// authorizePlayerProfile lets callers granted anyProfile through and
+// restricts the others, players, to their own profile
+func authorizePlayerProfile(ctx context.Context, playerID string, anyProfile auth.Permission) error {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return apperrors.Unauthorized()
+	}
+	if identity.Can(anyProfile) {
+		return nil
+	}
+
+	if identity.PlayerID == "" || identity.PlayerID != playerID {
+		return apperrors.Forbidden().WithDetails(map[string]interface{}{"player_id": playerID})
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type RankService interface {
+	GetAllRanksService(ctx context.Context) ([]models.Rank, error)
+	GetRankHistoryService(ctx context.Context, seasonID, playerID string) ([]models.RankChange, error)
+	RecalculateRanksService(ctx context.Context, seasonID string) ([]models.RankChange, error)
+}
+
+type rankService struct {
+	store *repository.Repository
+}
+
+func NewRankService(store *repository.Repository) RankService {
+	return &rankService{store: store}
+}
+
+func (s *rankService) GetAllRanksService(ctx context.Context) ([]models.Rank, error) {
+	ranks, err := s.store.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ranks == nil {
+		ranks = []models.Rank{}
+	}
+	return ranks, nil
+}
+
+func (s *rankService) GetRankHistoryService(ctx context.Context, seasonID, playerID string) ([]models.RankChange, error) {
+	history, err := s.store.Rank.GetRankHistoryRepo(ctx, seasonID, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if history == nil {
+		history = []models.RankChange{}
+	}
+	return history, nil
+}
+
+// RecalculateRanksService re-evaluates every rank of the season outside round
+// finalization; changes are recorded against the latest finalized round
+func (s *rankService) RecalculateRanksService(ctx context.Context, seasonID string) ([]models.RankChange, error) {
+	season, err := s.store.Season.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	var changes []models.RankChange
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		roundNumber, err := tx.Round.GetLatestFinalizedRoundRepo(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+
+		changes, err = recalculateRanks(ctx, tx, seasonID, roundNumber)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// recalculateRanks moves every player of the season to the rank given by the
+// buffer state machine and records each change in player_rank_history
+func recalculateRanks(ctx context.Context, tx *repository.Repository, seasonID string, roundNumber int) ([]models.RankChange, error) {
+	ranks, err := tx.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := loadSeasonSettings(ctx, tx.Settings, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	playerSeasons, err := tx.PlayerSeason.GetPlayerSeasonsBySeasonRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []models.RankChange{}
+	for _, ps := range playerSeasons {
+		newRankID := nextRank(ranks, ps.RankID, ps.AccumulatedPoints, settings)
+		if newRankID == ps.RankID {
+			continue
+		}
+
+		if err := tx.PlayerSeason.UpdatePlayerSeasonRankRepo(ctx, ps.ID, newRankID); err != nil {
+			return nil, err
+		}
+
+		change, err := tx.Rank.CreateRankHistoryRepo(ctx, &models.RankChange{
+			PlayerSeasonID: ps.ID,
+			PlayerID:       ps.PlayerID,
+			SeasonID:       seasonID,
+			RoundNumber:    roundNumber,
+			OldRankID:      ps.RankID,
+			NewRankID:      newRankID,
+			Points:         ps.AccumulatedPoints,
+		})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, *change)
+	}
+
+	return changes, nil
+}
+
+// nextRank applies the rank state machine. A player is promoted once their
+// points reach max_score + promotion buffer and relegated once they drop below
+// min_score - relegation buffer; inside the buffer the current rank is kept so
+// a close result does not flip the rank back and forth. ranks must be ordered
+// by sort_order.
+func nextRank(ranks []models.Rank, currentID string, points float64, settings *models.SeasonSettings) string {
+	idx := -1
+	for i, rank := range ranks {
+		if rank.ID == currentID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return currentID
+	}
+
+	for idx < len(ranks)-1 && ranks[idx].MaxScore != nil &&
+		points >= float64(*ranks[idx].MaxScore+settings.PromotionBuffer) {
+		idx++
+	}
+
+	for idx > 0 && ranks[idx].MinScore != nil &&
+		points < float64(*ranks[idx].MinScore-settings.RelegationBuffer) {
+		idx--
+	}
+
+	return ranks[idx].ID
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+// errDryRun rolls back the finalization transaction once every step has run
+var errDryRun = errors.New("dry run")
+
+type RoundService interface {
+	FinalizeRoundService(ctx context.Context, seasonID string, roundNumber int, dryRun bool) (*models.RoundFinalization, error)
+}
+
+// finalizeStep is one stage of round finalization. Steps run in order inside
+// a single transaction and record what they changed on the result.
+type finalizeStep func(ctx context.Context, tx *repository.Repository, result *models.RoundFinalization) error
+
+type roundService struct {
+	store *repository.Repository
+	steps []finalizeStep
+}
+
+func NewRoundService(store *repository.Repository) RoundService {
+	s := &roundService{store: store}
+	s.steps = []finalizeStep{
+		s.lockRoundStep,
+		s.roundPointsStep,
+		s.rankStep,
+		s.standingsStep,
+		s.teamStandingsStep,
+	}
+	return s
+}
+
+func (s *roundService) FinalizeRoundService(ctx context.Context, seasonID string, roundNumber int, dryRun bool) (*models.RoundFinalization, error) {
+	if roundNumber <= 0 {
+		return nil, apperrors.InvalidInput("round phải lớn hơn 0")
+	}
+
+	season, err := s.store.Season.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	result := &models.RoundFinalization{
+		SeasonID:    seasonID,
+		RoundNumber: roundNumber,
+		DryRun:      dryRun,
+	}
+
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		for _, step := range s.steps {
+			if err := step(ctx, tx, result); err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// lockRoundStep locks the round, checks every earlier round is finalized and
+// every fixture of the round is over, and marks the round FINALIZED
+func (s *roundService) lockRoundStep(ctx context.Context, tx *repository.Repository, result *models.RoundFinalization) error {
+	round, err := tx.Round.LockRoundRepo(ctx, result.SeasonID, result.RoundNumber)
+	if err != nil {
+		return err
+	}
+
+	if round.Status == models.RoundStatusFinalized {
+		return apperrors.RoundAlreadyFinalized()
+	}
+
+	open, err := tx.Round.GetOpenRoundsBeforeRepo(ctx, result.SeasonID, result.RoundNumber)
+	if err != nil {
+		return err
+	}
+	if len(open) > 0 {
+		return apperrors.PreviousRoundOpen().WithDetails(map[string][]int{"open_rounds": open})
+	}
+
+	fixtures, err := tx.Fixture.GetFixturesBySeasonRepo(ctx, result.SeasonID, models.FixtureFilter{Round: &result.RoundNumber})
+	if err != nil {
+		return err
+	}
+	if len(fixtures) == 0 {
+		return apperrors.RoundNotReady()
+	}
+
+	var pending []string
+	for _, fixture := range fixtures {
+		if fixture.Status != models.FixtureStatusCompleted && fixture.Status != models.FixtureStatusCancelled {
+			pending = append(pending, fixture.ID)
+		}
+	}
+	if len(pending) > 0 {
+		return apperrors.RoundNotReady().WithDetails(map[string][]string{"pending_fixture_ids": pending})
+	}
+
+	now := time.Now()
+	round.Status = models.RoundStatusFinalized
+	round.FinalizedAt = &now
+
+	result.Round, err = tx.Round.UpdateRoundRepo(ctx, round)
+	return err
+}
+
+// roundPointsStep stores the points each player earned in the round and adds
+// them to the player's accumulated points
+func (s *roundService) roundPointsStep(ctx context.Context, tx *repository.Repository, result *models.RoundFinalization) error {
+	points, err := tx.Round.GetPendingRoundPointsRepo(ctx, result.SeasonID, result.RoundNumber)
+	if err != nil {
+		return err
+	}
+
+	for i := range points {
+		if err := tx.Round.CreateRoundPointsRepo(ctx, &points[i]); err != nil {
+			return err
+		}
+
+		if points[i].PointsEarned == 0 {
+			continue
+		}
+
+		total, err := tx.PlayerSeason.AddAccumulatedPointsRepo(ctx, points[i].PlayerSeasonID, points[i].PointsEarned)
+		if err != nil {
+			return err
+		}
+		points[i].PointsAfter = total
+	}
+
+	result.Points = points
+	return nil
+}
+
+// rankStep promotes and relegates players using their updated points
+func (s *roundService) rankStep(ctx context.Context, tx *repository.Repository, result *models.RoundFinalization) error {
+	changes, err := recalculateRanks(ctx, tx, result.SeasonID, result.RoundNumber)
+	if err != nil {
+		return err
+	}
+
+	result.RankChanges = changes
+	return nil
+}
+
+// standingsStep snapshots the leaderboard as it stands after the round
+func (s *roundService) standingsStep(ctx context.Context, tx *repository.Repository, result *models.RoundFinalization) error {
+	standings, err := tx.Round.CreateRoundStandingsRepo(ctx, result.SeasonID, result.RoundNumber)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(standings, func(i, j int) bool {
+		return standings[i].RankPosition < standings[j].RankPosition
+	})
+
+	result.Standings = standings
+	return nil
+}
+
+// teamStandingsStep rebuilds the team standings so the table published with
+// the round reflects every fixture completed in it
+func (s *roundService) teamStandingsStep(ctx context.Context, tx *repository.Repository, result *models.RoundFinalization) error {
+	standings, err := recomputeTeamStandings(ctx, tx, result.SeasonID)
+	if err != nil {
+		return err
+	}
+
+	result.Teams = standings
+	return nil
+}
+
+// ensureRoundOpen rejects changes to a round that has already been finalized
+func ensureRoundOpen(ctx context.Context, tx *repository.Repository, seasonID string, roundNumber int) error {
+	round, err := tx.Round.GetRoundRepo(ctx, seasonID, roundNumber)
+	if err != nil {
+		return err
+	}
+
+	if round != nil && round.Status == models.RoundStatusFinalized {
+		return apperrors.RoundAlreadyFinalized()
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+// roundRepo keeps the rounds below the finalized one that are still open
+type roundRepo struct {
+	repository.RoundRepository
+	open    []int
+	updated bool
+}
+
+func (r *roundRepo) LockRoundRepo(ctx context.Context, seasonID string, roundNumber int) (*models.Round, error) {
+	return &models.Round{SeasonID: seasonID, RoundNumber: roundNumber, Status: models.RoundStatusOpen}, nil
+}
+
+func (r *roundRepo) GetOpenRoundsBeforeRepo(ctx context.Context, seasonID string, roundNumber int) ([]int, error) {
+	return r.open, nil
+}
+
+func (r *roundRepo) UpdateRoundRepo(ctx context.Context, round *models.Round) (*models.Round, error) {
+	r.updated = true
+	return round, nil
+}
+
+type roundSeasonRepo struct {
+	repository.SeasonRepository
+}
+
+func (r *roundSeasonRepo) GetSeasonByID(ctx context.Context, id string) (*models.Season, error) {
+	return &models.Season{ID: id}, nil
+}
+
+type roundFixtureRepo struct {
+	repository.FixtureRepository
+}
+
+func (r *roundFixtureRepo) GetFixturesBySeasonRepo(ctx context.Context, seasonID string, filter models.FixtureFilter) ([]models.Fixture, error) {
+	return nil, nil
+}
+
+func TestFinalizeRoundNeedsEarlierRoundsFinalized(t *testing.T) {
+	tests := []struct {
+		name     string
+		open     []int
+		wantCode string
+	}{
+		{
+			name:     "earlier round open",
+			open:     []int{2},
+			wantCode: apperrors.ErrorPreviousRoundOpen,
+		},
+		{
+			// With no fixtures the round stops at the readiness check next
+			name:     "earlier rounds finalized",
+			wantCode: apperrors.ErrorRoundNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds := &roundRepo{open: tt.open}
+			store := &repository.Repository{
+				Round:   rounds,
+				Season:  &roundSeasonRepo{},
+				Fixture: &roundFixtureRepo{},
+			}
+
+			_, err := NewRoundService(store).FinalizeRoundService(context.Background(), "season", 3, false)
+
+			var appErr *apperrors.AppError
+			if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+				t.Fatalf("FinalizeRoundService error = %v, want %s", err, tt.wantCode)
+			}
+			if rounds.updated {
+				t.Error("round was marked FINALIZED")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type ScheduleService interface {
+	GenerateScheduleService(ctx context.Context, seasonID string, req models.ScheduleRequest, preview bool) (*models.GeneratedSchedule, error)
+}
+
+type scheduleService struct {
+	store *repository.Repository
+}
+
+func NewScheduleService(store *repository.Repository) ScheduleService {
+	return &scheduleService{store: store}
+}
+
+// GenerateScheduleService builds a round-robin for every team of the season
+// and replaces the season's fixtures with it. Regeneration is refused once a
+// fixture has been played or a playoff bracket has been drawn from the
+// schedule. In preview mode the transaction is rolled back.
+func (s *scheduleService) GenerateScheduleService(ctx context.Context, seasonID string, req models.ScheduleRequest, preview bool) (*models.GeneratedSchedule, error) {
+	if req.StartRound == 0 {
+		req.StartRound = 1
+	}
+	if req.StartRound < 0 {
+		return nil, apperrors.InvalidInput("start_round phải lớn hơn 0")
+	}
+
+	season, err := s.store.Season.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	schedule := &models.GeneratedSchedule{
+		SeasonID: seasonID,
+		Preview:  preview,
+		Double:   req.Double,
+	}
+
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		teams, err := tx.Team.GetTeamsBySeasonIDRepo(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		if len(teams) < 2 {
+			return apperrors.InvalidInput("Mùa giải cần ít nhất 2 đội để xếp lịch")
+		}
+
+		brackets, err := tx.Bracket.GetBracketsBySeasonRepo(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		if len(brackets) > 0 {
+			return apperrors.ScheduleHasBracket()
+		}
+
+		existing, err := tx.Fixture.GetFixturesBySeasonRepo(ctx, seasonID, models.FixtureFilter{})
+		if err != nil {
+			return err
+		}
+		for _, fixture := range existing {
+			if fixture.Status == models.FixtureStatusOngoing || fixture.Status == models.FixtureStatusCompleted {
+				return apperrors.ScheduleLocked()
+			}
+		}
+
+		latest, err := tx.Round.GetLatestFinalizedRoundRepo(ctx, seasonID)
+		if err != nil {
+			return err
+		}
+		if req.StartRound <= latest {
+			return apperrors.RoundAlreadyFinalized()
+		}
+
+		teamIDs := make([]string, len(teams))
+		for i, team := range teams {
+			teamIDs[i] = team.ID
+		}
+		fixtures, byes, rounds := roundRobin(teamIDs, req.Double)
+
+		schedule.Rounds = rounds
+		schedule.Byes = byes
+		for i := range schedule.Byes {
+			schedule.Byes[i].Round += req.StartRound - 1
+		}
+
+		if err := tx.Fixture.DeleteFixturesBySeasonRepo(ctx, seasonID); err != nil {
+			return err
+		}
+
+		schedule.Fixtures = make([]models.Fixture, 0, len(fixtures))
+		for _, fixture := range fixtures {
+			fixture.SeasonID = seasonID
+			fixture.Round += req.StartRound - 1
+			if !preview {
+				if _, err := tx.Fixture.CreateFixtureRepo(ctx, &fixture); err != nil {
+					return err
+				}
+			} else {
+				fixture.Status = models.FixtureStatusScheduled
+			}
+			schedule.Fixtures = append(schedule.Fixtures, fixture)
+		}
+
+		if preview {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return nil, err
+	}
+
+	if schedule.Byes == nil {
+		schedule.Byes = []models.ScheduleBye{}
+	}
+	return schedule, nil
+}
+
+// roundRobin pairs the teams with the circle method: one slot stays fixed and
+// the others rotate by one position each round. With an odd number of teams
+// the fixed slot is a bye, which keeps home and away games within one of each
+// other for every team. A double round-robin repeats the rounds with home and
+// away swapped. Rounds are numbered from 1.
+func roundRobin(teamIDs []string, double bool) ([]models.Fixture, []models.ScheduleBye, int) {
+	slots := append([]string(nil), teamIDs...)
+	if len(slots)%2 == 1 {
+		slots = append([]string{""}, slots...)
+	}
+
+	n := len(slots)
+	rounds := n - 1
+
+	var fixtures []models.Fixture
+	var byes []models.ScheduleBye
+	for round := 1; round <= rounds; round++ {
+		for i := 0; i < n/2; i++ {
+			home, guest := slots[i], slots[n-1-i]
+			// Alternate the fixed slot every round and the other pairs by table position
+			if (i == 0 && round%2 == 0) || (i > 0 && i%2 == 1) {
+				home, guest = guest, home
+			}
+
+			switch {
+			case home == "":
+				byes = append(byes, models.ScheduleBye{Round: round, TeamID: guest})
+			case guest == "":
+				byes = append(byes, models.ScheduleBye{Round: round, TeamID: home})
+			default:
+				fixtures = append(fixtures, models.Fixture{Round: round, HomeTeamID: home, GuestTeamID: guest})
+			}
+		}
+
+		// Rotate every slot but the first one position to the right
+		last := slots[n-1]
+		copy(slots[2:], slots[1:n-1])
+		slots[1] = last
+	}
+
+	if !double {
+		return fixtures, byes, rounds
+	}
+
+	// The second leg mirrors the first with home and away swapped
+	firstLegFixtures, firstLegByes := len(fixtures), len(byes)
+	for _, fixture := range fixtures[:firstLegFixtures] {
+		fixtures = append(fixtures, models.Fixture{
+			Round:       fixture.Round + rounds,
+			HomeTeamID:  fixture.GuestTeamID,
+			GuestTeamID: fixture.HomeTeamID,
+		})
+	}
+	for _, bye := range byes[:firstLegByes] {
+		byes = append(byes, models.ScheduleBye{Round: bye.Round + rounds, TeamID: bye.TeamID})
+	}
+
+	return fixtures, byes, rounds * 2
+}
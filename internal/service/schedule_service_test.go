@@ -0,0 +1,137 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+)
+
+func roundRobinTeams(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("team-%d", i+1)
+	}
+	return ids
+}
+
+func TestRoundRobin(t *testing.T) {
+	for n := 2; n <= 10; n++ {
+		t.Run(fmt.Sprintf("%d teams", n), func(t *testing.T) {
+			teams := roundRobinTeams(n)
+			fixtures, byes, rounds := roundRobin(teams, false)
+
+			wantRounds := n - 1
+			if n%2 == 1 {
+				wantRounds = n
+			}
+			if rounds != wantRounds {
+				t.Fatalf("rounds = %d, want %d", rounds, wantRounds)
+			}
+			if want := n * (n - 1) / 2; len(fixtures) != want {
+				t.Fatalf("fixtures = %d, want %d", len(fixtures), want)
+			}
+
+			pairs := make(map[[2]string]bool)
+			home := make(map[string]int)
+			away := make(map[string]int)
+			busy := make(map[int]map[string]bool)
+			play := func(round int, teamID string) {
+				if round < 1 || round > rounds {
+					t.Fatalf("round %d out of 1..%d", round, rounds)
+				}
+				if busy[round] == nil {
+					busy[round] = make(map[string]bool)
+				}
+				if busy[round][teamID] {
+					t.Fatalf("%s plays twice in round %d", teamID, round)
+				}
+				busy[round][teamID] = true
+			}
+
+			for _, fixture := range fixtures {
+				if fixture.HomeTeamID == fixture.GuestTeamID {
+					t.Fatalf("%s plays itself in round %d", fixture.HomeTeamID, fixture.Round)
+				}
+				pair := [2]string{fixture.HomeTeamID, fixture.GuestTeamID}
+				if pair[0] > pair[1] {
+					pair[0], pair[1] = pair[1], pair[0]
+				}
+				if pairs[pair] {
+					t.Fatalf("%s and %s meet twice", pair[0], pair[1])
+				}
+				pairs[pair] = true
+
+				home[fixture.HomeTeamID]++
+				away[fixture.GuestTeamID]++
+				play(fixture.Round, fixture.HomeTeamID)
+				play(fixture.Round, fixture.GuestTeamID)
+			}
+
+			wantByes := 0
+			if n%2 == 1 {
+				wantByes = n
+			}
+			if len(byes) != wantByes {
+				t.Fatalf("byes = %d, want %d", len(byes), wantByes)
+			}
+			for _, bye := range byes {
+				play(bye.Round, bye.TeamID)
+			}
+
+			// Every team plays or rests in every round
+			for round := 1; round <= rounds; round++ {
+				if len(busy[round]) != n {
+					t.Errorf("round %d has %d teams, want %d", round, len(busy[round]), n)
+				}
+			}
+
+			for _, teamID := range teams {
+				diff := home[teamID] - away[teamID]
+				if diff < -1 || diff > 1 {
+					t.Errorf("%s plays %d home and %d away", teamID, home[teamID], away[teamID])
+				}
+			}
+		})
+	}
+}
+
+func TestRoundRobinDouble(t *testing.T) {
+	for _, n := range []int{4, 5} {
+		t.Run(fmt.Sprintf("%d teams", n), func(t *testing.T) {
+			firstLeg, firstByes, firstRounds := roundRobin(roundRobinTeams(n), false)
+			fixtures, byes, rounds := roundRobin(roundRobinTeams(n), true)
+
+			if rounds != 2*firstRounds {
+				t.Fatalf("rounds = %d, want %d", rounds, 2*firstRounds)
+			}
+			if len(fixtures) != 2*len(firstLeg) || len(byes) != 2*len(firstByes) {
+				t.Fatalf("fixtures, byes = %d, %d, want %d, %d", len(fixtures), len(byes), 2*len(firstLeg), 2*len(firstByes))
+			}
+
+			for i, first := range firstLeg {
+				if fixtures[i] != first {
+					t.Errorf("first leg fixture %d = %+v, want %+v", i, fixtures[i], first)
+				}
+				second := fixtures[len(firstLeg)+i]
+				if second.Round != first.Round+firstRounds || second.HomeTeamID != first.GuestTeamID || second.GuestTeamID != first.HomeTeamID {
+					t.Errorf("second leg fixture %d = %+v, want %+v mirrored %d rounds later", i, second, first, firstRounds)
+				}
+			}
+			for i, first := range firstByes {
+				second := byes[len(firstByes)+i]
+				if second.TeamID != first.TeamID || second.Round != first.Round+firstRounds {
+					t.Errorf("second leg bye %d = %+v, want %+v %d rounds later", i, second, first, firstRounds)
+				}
+			}
+
+			// Each team hosts every other team exactly once
+			seen := make(map[[2]string]bool)
+			for _, fixture := range fixtures {
+				pair := [2]string{fixture.HomeTeamID, fixture.GuestTeamID}
+				if seen[pair] {
+					t.Fatalf("%s hosts %s twice", pair[0], pair[1])
+				}
+				seen[pair] = true
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	apperrors "backend-ping-pong-app/internal/errors"
 	"backend-ping-pong-app/internal/models"
 	"backend-ping-pong-app/internal/repository"
 )
@@ -15,14 +16,18 @@ type SeasonService interface {
 	GetSeasonByID(ctx context.Context, id string) (*models.Season, error)
 	CreateSeason(ctx context.Context, season *models.Season) (*models.Season, error)
 	UpdateSeason(ctx context.Context, season *models.Season) (*models.Season, error)
+	GetSeasonSettings(ctx context.Context, seasonID string) (*models.SeasonSettings, error)
+	UpdateSeasonSettings(ctx context.Context, seasonID string, update models.SeasonSettingsUpdate) (*models.SeasonSettings, error)
 }
 
 type seasonService struct {
-	repo repository.SeasonRepository
+	store        *repository.Repository
+	repo         repository.SeasonRepository
+	settingsRepo repository.SeasonSettingsRepository
 }
 
-func NewSeasonService(repo repository.SeasonRepository) SeasonService {
-	return &seasonService{repo: repo}
+func NewSeasonService(store *repository.Repository) SeasonService {
+	return &seasonService{store: store, repo: store.Season, settingsRepo: store.Settings}
 }
 
 func (s *seasonService) GetAllSeasons(ctx context.Context) ([]models.SeasonListResponse, error) {
@@ -64,3 +69,99 @@ func (s *seasonService) CreateSeason(ctx context.Context, season *models.Season)
 func (s *seasonService) UpdateSeason(ctx context.Context, season *models.Season) (*models.Season, error) {
 	return s.repo.UpdateSeason(ctx, season)
 }
+
+func (s *seasonService) GetSeasonSettings(ctx context.Context, seasonID string) (*models.SeasonSettings, error) {
+	if err := s.ensureSeasonExists(ctx, seasonID); err != nil {
+		return nil, err
+	}
+
+	return loadSeasonSettings(ctx, s.settingsRepo, seasonID)
+}
+
+func (s *seasonService) UpdateSeasonSettings(ctx context.Context, seasonID string, update models.SeasonSettingsUpdate) (*models.SeasonSettings, error) {
+	settings, err := s.GetSeasonSettings(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	pointsBefore := [3]int{settings.PointsPerWin, settings.PointsPerDraw, settings.PointsPerLoss}
+	update.Apply(settings)
+	if err := validateSeasonSettings(settings); err != nil {
+		return nil, err
+	}
+
+	var saved *models.SeasonSettings
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		saved, err = tx.Settings.UpsertSeasonSettingsRepo(ctx, settings)
+		if err != nil {
+			return err
+		}
+
+		// The stored standings carry the points of the old rules
+		if pointsBefore != [3]int{saved.PointsPerWin, saved.PointsPerDraw, saved.PointsPerLoss} {
+			_, err = recomputeTeamStandings(ctx, tx, seasonID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func validateSeasonSettings(settings *models.SeasonSettings) error {
+	if settings.PromotionBuffer < 0 || settings.RelegationBuffer < 0 {
+		return apperrors.InvalidInput("buffer không được là số âm")
+	}
+
+	start, end := settings.TransferWindowStartRound, settings.TransferWindowEndRound
+	if (start != nil && *start <= 0) || (end != nil && *end <= 0) {
+		return apperrors.InvalidInput("Vòng của kỳ chuyển nhượng phải lớn hơn 0")
+	}
+	if start != nil && end != nil && *start > *end {
+		return apperrors.InvalidInput("Vòng bắt đầu kỳ chuyển nhượng phải nhỏ hơn vòng kết thúc")
+	}
+	if settings.MaxTransfersPerPlayer < 0 {
+		return apperrors.InvalidInput("max_transfers_per_player không được là số âm")
+	}
+	if settings.PointsPerWin < settings.PointsPerDraw || settings.PointsPerDraw < settings.PointsPerLoss {
+		return apperrors.InvalidInput("Điểm thắng phải không nhỏ hơn điểm hòa và điểm hòa không nhỏ hơn điểm thua")
+	}
+	if settings.HandicapStep <= 0 || settings.HandicapMaxPoints < 0 {
+		return apperrors.InvalidInput("handicap_step phải lớn hơn 0 và handicap_max_points không được là số âm")
+	}
+	if settings.MaxAppearancesPerFixture < 1 {
+		return apperrors.InvalidInput("max_appearances_per_fixture phải lớn hơn 0")
+	}
+	if settings.NoShowPenaltyPoints < 0 {
+		return apperrors.InvalidInput("no_show_penalty_points không được là số âm")
+	}
+
+	return nil
+}
+
+func (s *seasonService) ensureSeasonExists(ctx context.Context, seasonID string) error {
+	season, err := s.repo.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return err
+	}
+
+	if season == nil {
+		return apperrors.SeasonNotFound()
+	}
+	return nil
+}
+
+// loadSeasonSettings returns the season's settings, falling back to the defaults
+func loadSeasonSettings(ctx context.Context, repo repository.SeasonSettingsRepository, seasonID string) (*models.SeasonSettings, error) {
+	settings, err := repo.GetSeasonSettingsRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings == nil {
+		return models.DefaultSeasonSettings(seasonID), nil
+	}
+	return settings, nil
+}
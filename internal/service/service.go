@@ -1,19 +1,65 @@
 package service
 
-import "backend-ping-pong-app/internal/repository"
+import (
+	"backend-ping-pong-app/internal/auth"
+	"backend-ping-pong-app/internal/config"
+	"backend-ping-pong-app/internal/rating"
+	"backend-ping-pong-app/internal/repository"
+)
 
 // Service là struct gốc, chứa toàn bộ service của app
 type Service struct {
-	Player PlayerService
-	Season SeasonService
-	Team   TeamService
+	Player       PlayerService
+	Season       SeasonService
+	Team         TeamService
+	Fixture      FixtureService
+	Lineup       LineupService
+	Match        MatchService
+	Amendment    AmendmentService
+	Round        RoundService
+	Rank         RankService
+	Leaderboard  LeaderboardService
+	PlayerSeason PlayerSeasonService
+	Transfer     TransferService
+	Standings    StandingsService
+	Schedule     ScheduleService
+	Bracket      BracketService
+	Tournament   TournamentService
+	Auth         AuthService
+	Admin        AdminService
+	APIKey       APIKeyService
 }
 
 // NewService khởi tạo toàn bộ service
-func NewService(repo *repository.Repository) *Service {
+func NewService(repo *repository.Repository, cfg *config.Config) *Service {
 	return &Service{
-		Player: NewPlayerService(repo.Player),
-		Season: NewSeasonService(repo.Season),
-		Team:   NewTeamService(repo.Team),
+		Player:       NewPlayerService(repo.Player),
+		Season:       NewSeasonService(repo),
+		Team:         NewTeamService(repo.Team, repo.Admin),
+		Fixture:      NewFixtureService(repo.Fixture, repo.Team),
+		Lineup:       NewLineupService(repo, cfg.League),
+		Match:        NewMatchService(repo, cfg.League, rating.NewElo()),
+		Amendment:    NewAmendmentService(repo, cfg.League, rating.NewElo()),
+		Round:        NewRoundService(repo),
+		Rank:         NewRankService(repo),
+		Leaderboard:  NewLeaderboardService(repo.Leaderboard, repo.Round),
+		PlayerSeason: NewPlayerSeasonService(repo),
+		Transfer:     NewTransferService(repo),
+		Standings:    NewStandingsService(repo),
+		Schedule:     NewScheduleService(repo),
+		Bracket:      NewBracketService(repo),
+		Tournament:   NewTournamentService(repo, cfg.League, rating.NewElo()),
+		Auth:         NewAuthService(repo.Admin, repo.Player, auth.NewTokenIssuer(cfg.Auth.JWTSecret, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL), newFirebaseVerifier(cfg.Auth)),
+		Admin:        NewAdminService(repo),
+		APIKey:       NewAPIKeyService(repo.APIKey),
 	}
 }
+
+// newFirebaseVerifier returns nil, turning player sign-in off, when no
+// Firebase project is configured
+func newFirebaseVerifier(cfg config.AuthConfig) *auth.FirebaseVerifier {
+	if cfg.FirebaseProjectID == "" {
+		return nil
+	}
+	return auth.NewFirebaseVerifier(cfg.FirebaseProjectID, auth.NewHTTPKeySource(cfg.FirebaseKeysURL))
+}
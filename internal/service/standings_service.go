@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type StandingsService interface {
+	GetSeasonStandingsService(ctx context.Context, seasonID string) ([]models.TeamStanding, error)
+}
+
+type standingsService struct {
+	store *repository.Repository
+}
+
+func NewStandingsService(store *repository.Repository) StandingsService {
+	return &standingsService{store: store}
+}
+
+// GetSeasonStandingsService returns the stored team standings. Before the
+// first fixture is completed nothing is stored yet, so the table is computed
+// on the fly with every team at zero.
+func (s *standingsService) GetSeasonStandingsService(ctx context.Context, seasonID string) ([]models.TeamStanding, error) {
+	season, err := s.store.Season.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	standings, err := s.store.Standing.GetTeamStandingsRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(standings) == 0 {
+		standings, err = computeTeamStandings(ctx, s.store, seasonID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if standings == nil {
+		standings = []models.TeamStanding{}
+	}
+	return standings, nil
+}
+
+// recomputeTeamStandings rebuilds the season's team standings from its
+// completed fixtures and stores them
+func recomputeTeamStandings(ctx context.Context, tx *repository.Repository, seasonID string) ([]models.TeamStanding, error) {
+	standings, err := computeTeamStandings(ctx, tx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Standing.ReplaceTeamStandingsRepo(ctx, seasonID, standings); err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
+
+func computeTeamStandings(ctx context.Context, repo *repository.Repository, seasonID string) ([]models.TeamStanding, error) {
+	settings, err := loadSeasonSettings(ctx, repo.Settings, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := repo.Team.GetTeamsBySeasonIDRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := repo.Standing.GetFixtureResultsRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	byTeam := make(map[string]*models.TeamStanding, len(teams))
+	standings := make([]models.TeamStanding, len(teams))
+	for i, team := range teams {
+		standings[i] = models.TeamStanding{
+			SeasonID:  seasonID,
+			TeamID:    team.ID,
+			TeamName:  team.Name,
+			UpdatedAt: now,
+		}
+		byTeam[team.ID] = &standings[i]
+	}
+
+	for _, result := range results {
+		home, guest := byTeam[result.HomeTeamID], byTeam[result.GuestTeamID]
+		if home == nil || guest == nil {
+			continue
+		}
+		addFixtureResult(home, result.HomeRubbers, result.GuestRubbers, result.HomeSets, result.GuestSets, result.HomePoints, result.GuestPoints, settings)
+		addFixtureResult(guest, result.GuestRubbers, result.HomeRubbers, result.GuestSets, result.HomeSets, result.GuestPoints, result.HomePoints, settings)
+	}
+
+	sortTeamStandings(standings, results, settings)
+	return standings, nil
+}
+
+func addFixtureResult(standing *models.TeamStanding, rubbersWon, rubbersLost, setsWon, setsLost, pointsWon, pointsLost int, settings *models.SeasonSettings) {
+	standing.Played++
+	standing.RubbersWon += rubbersWon
+	standing.RubbersLost += rubbersLost
+	standing.SetsWon += setsWon
+	standing.SetsLost += setsLost
+	standing.PointsWon += pointsWon
+	standing.PointsLost += pointsLost
+	standing.TotalPoints += resultPoints(rubbersWon, rubbersLost, settings)
+
+	switch {
+	case rubbersWon > rubbersLost:
+		standing.Wins++
+	case rubbersWon < rubbersLost:
+		standing.Losses++
+	default:
+		standing.Draws++
+	}
+}
+
+// resultPoints returns the table points a side earns for a fixture
+func resultPoints(rubbersWon, rubbersLost int, settings *models.SeasonSettings) int {
+	switch {
+	case rubbersWon > rubbersLost:
+		return settings.PointsPerWin
+	case rubbersWon < rubbersLost:
+		return settings.PointsPerLoss
+	default:
+		return settings.PointsPerDraw
+	}
+}
+
+// sortTeamStandings orders teams by total points. Teams level on points are
+// separated by the points earned in the fixtures between them (head-to-head),
+// then by rubber, set and point difference, and finally by name.
+func sortTeamStandings(standings []models.TeamStanding, results []models.FixtureResult, settings *models.SeasonSettings) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].TotalPoints > standings[j].TotalPoints
+	})
+
+	for start := 0; start < len(standings); {
+		end := start + 1
+		for end < len(standings) && standings[end].TotalPoints == standings[start].TotalPoints {
+			end++
+		}
+
+		group := standings[start:end]
+		headToHead := headToHeadPoints(group, results, settings)
+		sort.SliceStable(group, func(i, j int) bool {
+			a, b := group[i], group[j]
+			if headToHead[a.TeamID] != headToHead[b.TeamID] {
+				return headToHead[a.TeamID] > headToHead[b.TeamID]
+			}
+			if d1, d2 := a.RubbersWon-a.RubbersLost, b.RubbersWon-b.RubbersLost; d1 != d2 {
+				return d1 > d2
+			}
+			if d1, d2 := a.SetsWon-a.SetsLost, b.SetsWon-b.SetsLost; d1 != d2 {
+				return d1 > d2
+			}
+			if d1, d2 := a.PointsWon-a.PointsLost, b.PointsWon-b.PointsLost; d1 != d2 {
+				return d1 > d2
+			}
+			return a.TeamName < b.TeamName
+		})
+
+		start = end
+	}
+
+	for i := range standings {
+		standings[i].Position = i + 1
+	}
+}
+
+// headToHeadPoints returns the table points each team of a tied group earned
+// in fixtures played against the other teams of the group
+func headToHeadPoints(group []models.TeamStanding, results []models.FixtureResult, settings *models.SeasonSettings) map[string]int {
+	points := make(map[string]int, len(group))
+	if len(group) < 2 {
+		return points
+	}
+
+	inGroup := make(map[string]bool, len(group))
+	for _, standing := range group {
+		inGroup[standing.TeamID] = true
+	}
+
+	for _, result := range results {
+		if !inGroup[result.HomeTeamID] || !inGroup[result.GuestTeamID] {
+			continue
+		}
+		points[result.HomeTeamID] += resultPoints(result.HomeRubbers, result.GuestRubbers, settings)
+		points[result.GuestTeamID] += resultPoints(result.GuestRubbers, result.HomeRubbers, settings)
+	}
+
+	return points
+}
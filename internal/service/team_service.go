@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	apperrors "backend-ping-pong-app/internal/errors"
 	"backend-ping-pong-app/internal/models"
 	"backend-ping-pong-app/internal/repository"
 	"backend-ping-pong-app/internal/utils"
@@ -16,14 +17,18 @@ type TeamService interface {
 	GetTeamByIDService(ctx context.Context, id string) (*models.Team, error)
 	CreateTeamService(ctx context.Context, team *models.Team) (*models.Team, error)
 	GetTeamMembersService(ctx context.Context, teamID string) ([]models.TeamMember, error)
+	GetTeamCaptainsService(ctx context.Context, teamID string) ([]models.Admin, error)
+	AddTeamCaptainService(ctx context.Context, teamID, adminID string) ([]models.Admin, error)
+	RemoveTeamCaptainService(ctx context.Context, teamID, adminID string) ([]models.Admin, error)
 }
 
 type teamService struct {
-	repo repository.TeamRepository
+	repo      repository.TeamRepository
+	adminRepo repository.AdminRepository
 }
 
-func NewTeamService(repo repository.TeamRepository) TeamService {
-	return &teamService{repo: repo}
+func NewTeamService(repo repository.TeamRepository, adminRepo repository.AdminRepository) TeamService {
+	return &teamService{repo: repo, adminRepo: adminRepo}
 }
 
 func (s *teamService) GetTeamsBySeasonIDService(ctx context.Context, seasonID string) ([]models.TeamListResponse, error) {
@@ -68,3 +73,70 @@ func (s *teamService) CreateTeamService(ctx context.Context, team *models.Team)
 func (s *teamService) GetTeamMembersService(ctx context.Context, teamID string) ([]models.TeamMember, error) {
 	return s.repo.GetTeamMembersRepo(ctx, teamID)
 }
+
+func (s *teamService) GetTeamCaptainsService(ctx context.Context, teamID string) ([]models.Admin, error) {
+	if err := s.ensureTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+	return s.teamCaptains(ctx, teamID)
+}
+
+// AddTeamCaptainService lets a CAPTAIN account submit the team's lineups
+func (s *teamService) AddTeamCaptainService(ctx context.Context, teamID, adminID string) ([]models.Admin, error) {
+	if err := s.ensureTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.adminRepo.GetAdminByIDRepo(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin == nil {
+		return nil, apperrors.AdminNotFound()
+	}
+	if admin.Role != models.AdminRoleCaptain {
+		return nil, apperrors.InvalidInput("Chỉ tài khoản đội trưởng mới được gán cho đội").WithDetails(map[string]interface{}{
+			"admin_id": admin.ID,
+			"role":     admin.Role,
+		})
+	}
+
+	if err := s.adminRepo.AddTeamCaptainRepo(ctx, teamID, adminID); err != nil {
+		return nil, err
+	}
+	return s.teamCaptains(ctx, teamID)
+}
+
+func (s *teamService) RemoveTeamCaptainService(ctx context.Context, teamID, adminID string) ([]models.Admin, error) {
+	if err := s.ensureTeam(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	if err := s.adminRepo.RemoveTeamCaptainRepo(ctx, teamID, adminID); err != nil {
+		return nil, err
+	}
+	return s.teamCaptains(ctx, teamID)
+}
+
+func (s *teamService) ensureTeam(ctx context.Context, teamID string) error {
+	team, err := s.repo.GetTeamByIDRepo(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team == nil {
+		return apperrors.TeamNotFound()
+	}
+	return nil
+}
+
+func (s *teamService) teamCaptains(ctx context.Context, teamID string) ([]models.Admin, error) {
+	captains, err := s.adminRepo.GetTeamCaptainsRepo(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if captains == nil {
+		captains = []models.Admin{}
+	}
+	return captains, nil
+}
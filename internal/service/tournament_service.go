@@ -0,0 +1,634 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"backend-ping-pong-app/internal/config"
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/rating"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type TournamentService interface {
+	GetTournamentsService(ctx context.Context, seasonID string) ([]models.Tournament, error)
+	GetTournamentService(ctx context.Context, id string) (*models.TournamentDetail, error)
+	CreateTournamentService(ctx context.Context, seasonID string, req models.CreateTournamentRequest) (*models.Tournament, error)
+	AddEntriesService(ctx context.Context, id string, playerIDs []string) ([]models.TournamentEntry, error)
+	DrawGroupsService(ctx context.Context, id string) (*models.TournamentDetail, error)
+	RecordResultService(ctx context.Context, id, matchID string, req models.TournamentResultRequest) (*models.TournamentMatch, error)
+}
+
+type tournamentService struct {
+	store  *repository.Repository
+	league config.LeagueConfig
+	rating rating.Calculator
+}
+
+func NewTournamentService(store *repository.Repository, league config.LeagueConfig, calculator rating.Calculator) TournamentService {
+	return &tournamentService{store: store, league: league, rating: calculator}
+}
+
+func (s *tournamentService) GetTournamentsService(ctx context.Context, seasonID string) ([]models.Tournament, error) {
+	tournaments, err := s.store.Tournament.GetTournamentsBySeasonRepo(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tournaments == nil {
+		tournaments = []models.Tournament{}
+	}
+	return tournaments, nil
+}
+
+func (s *tournamentService) GetTournamentService(ctx context.Context, id string) (*models.TournamentDetail, error) {
+	tournament, err := s.store.Tournament.GetTournamentByIDRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == nil {
+		return nil, apperrors.TournamentNotFound()
+	}
+
+	return buildTournamentDetail(ctx, s.store, tournament)
+}
+
+func (s *tournamentService) CreateTournamentService(ctx context.Context, seasonID string, req models.CreateTournamentRequest) (*models.Tournament, error) {
+	if req.GroupCount <= 0 || req.QualifiersPerGroup <= 0 {
+		return nil, apperrors.InvalidInput("group_count và qualifiers_per_group phải lớn hơn 0")
+	}
+	if req.GroupCount*req.QualifiersPerGroup < 2 {
+		return nil, apperrors.InvalidInput("Vòng loại trực tiếp cần ít nhất 2 VĐV")
+	}
+
+	season, err := s.store.Season.GetSeasonByID(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	if season == nil {
+		return nil, apperrors.SeasonNotFound()
+	}
+
+	return s.store.Tournament.CreateTournamentRepo(ctx, &models.Tournament{
+		SeasonID:           seasonID,
+		Name:               req.Name,
+		GroupCount:         req.GroupCount,
+		QualifiersPerGroup: req.QualifiersPerGroup,
+	})
+}
+
+// AddEntriesService enters players while registration is open. Every player
+// must be registered in the tournament's season, which gives them a rating
+// and a team.
+func (s *tournamentService) AddEntriesService(ctx context.Context, id string, playerIDs []string) ([]models.TournamentEntry, error) {
+	if len(playerIDs) == 0 {
+		return nil, apperrors.InvalidInput("player_ids không được để trống")
+	}
+
+	var entries []models.TournamentEntry
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		tournament, err := lockTournament(ctx, tx, id, models.TournamentStatusRegistration)
+		if err != nil {
+			return err
+		}
+
+		for _, playerID := range playerIDs {
+			ps, err := tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, tournament.SeasonID, playerID)
+			if err != nil {
+				return err
+			}
+			if ps == nil {
+				return apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"player_id": playerID})
+			}
+
+			entry := &models.TournamentEntry{
+				TournamentID:   id,
+				PlayerID:       playerID,
+				PlayerSeasonID: ps.ID,
+				TeamID:         &ps.TeamID,
+			}
+			if _, err := tx.Tournament.CreateEntryRepo(ctx, entry); err != nil {
+				if repository.IsUniqueViolation(err) {
+					return apperrors.InvalidInput("VĐV đã đăng ký giải đấu").WithDetails(map[string]string{"player_id": playerID})
+				}
+				return err
+			}
+		}
+
+		entries, err = tx.Tournament.GetEntriesRepo(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// DrawGroupsService seeds the entries by season points, spreads them over the
+// groups and creates the round-robin of every group
+func (s *tournamentService) DrawGroupsService(ctx context.Context, id string) (*models.TournamentDetail, error) {
+	var tournament *models.Tournament
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		var err error
+		tournament, err = lockTournament(ctx, tx, id, models.TournamentStatusRegistration)
+		if err != nil {
+			return err
+		}
+
+		entries, err := tx.Tournament.GetEntriesRepo(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(entries) < 2*tournament.GroupCount {
+			return apperrors.InvalidInput("Mỗi bảng cần ít nhất 2 VĐV")
+		}
+		if len(entries)/tournament.GroupCount < tournament.QualifiersPerGroup {
+			return apperrors.InvalidInput("Số VĐV đi tiếp vượt quá số VĐV của bảng")
+		}
+
+		points := make(map[string]float64, len(entries))
+		for _, entry := range entries {
+			ps, err := tx.PlayerSeason.GetPlayerSeasonByIDRepo(ctx, entry.PlayerSeasonID)
+			if err != nil {
+				return err
+			}
+			if ps != nil {
+				points[entry.ID] = ps.AccumulatedPoints
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			if points[entries[i].ID] != points[entries[j].ID] {
+				return points[entries[i].ID] > points[entries[j].ID]
+			}
+			return entries[i].PlayerName < entries[j].PlayerName
+		})
+
+		groups := drawGroups(entries, tournament.GroupCount)
+		members := make(map[int][]string, tournament.GroupCount)
+		for i, entry := range entries {
+			if err := tx.Tournament.UpdateEntryDrawRepo(ctx, entry.ID, i+1, groups[i]); err != nil {
+				return err
+			}
+			members[groups[i]] = append(members[groups[i]], entry.ID)
+		}
+
+		for group := 1; group <= tournament.GroupCount; group++ {
+			// The circle method pairs entry ids the same way it pairs teams
+			pairings, _, _ := roundRobin(members[group], false)
+			position := make(map[int]int)
+			for _, pairing := range pairings {
+				groupNumber, home, guest := group, pairing.HomeTeamID, pairing.GuestTeamID
+				match := &models.TournamentMatch{
+					TournamentID: id,
+					Stage:        models.TournamentStageGroup,
+					GroupNumber:  &groupNumber,
+					Round:        pairing.Round,
+					Position:     position[pairing.Round],
+					HomeEntryID:  &home,
+					GuestEntryID: &guest,
+				}
+				position[pairing.Round]++
+				if _, err := tx.Tournament.CreateTournamentMatchRepo(ctx, match); err != nil {
+					return err
+				}
+			}
+		}
+
+		tournament.Status = models.TournamentStatusGroupStage
+		return tx.Tournament.UpdateTournamentStatusRepo(ctx, id, tournament.Status)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTournamentDetail(ctx, s.store, tournament)
+}
+
+// drawGroups returns the group number of every entry. Entries are taken in
+// seed order, one pot of group_count entries at a time, and dealt in a
+// serpentine. Within a pot an entry goes to the first remaining group without
+// a player of the same team, so teammates only meet in the group stage when
+// there is no other choice.
+func drawGroups(entries []models.TournamentEntry, groupCount int) []int {
+	result := make([]int, len(entries))
+	teams := make([]map[string]bool, groupCount)
+	for i := range teams {
+		teams[i] = make(map[string]bool)
+	}
+
+	for start := 0; start < len(entries); start += groupCount {
+		available := make([]int, groupCount)
+		for i := range available {
+			available[i] = i
+			if (start/groupCount)%2 == 1 {
+				available[i] = groupCount - 1 - i
+			}
+		}
+
+		for i := start; i < start+groupCount && i < len(entries); i++ {
+			pick := 0
+			if team := entries[i].TeamID; team != nil {
+				for k, group := range available {
+					if !teams[group][*team] {
+						pick = k
+						break
+					}
+				}
+			}
+
+			group := available[pick]
+			available = append(available[:pick], available[pick+1:]...)
+			result[i] = group + 1
+			if team := entries[i].TeamID; team != nil {
+				teams[group][*team] = true
+			}
+		}
+	}
+
+	return result
+}
+
+// RecordResultService stores the score of a tournament match and applies the
+// rating change of both players straight away, logged with the TOURNAMENT
+// source. Finishing the group stage draws the knockout; winning a knockout
+// match moves the player on.
+func (s *tournamentService) RecordResultService(ctx context.Context, id, matchID string, req models.TournamentResultRequest) (*models.TournamentMatch, error) {
+	homeWins, guestWins, err := validateSetScores(req.HomeSets, req.GuestSets, s.league.BestOf)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *models.TournamentMatch
+	err = s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		tournament, err := tx.Tournament.LockTournamentRepo(ctx, id)
+		if err != nil {
+			return err
+		}
+		if tournament == nil {
+			return apperrors.TournamentNotFound()
+		}
+
+		match, err = tx.Tournament.LockTournamentMatchRepo(ctx, matchID)
+		if err != nil {
+			return err
+		}
+		if match == nil || match.TournamentID != id {
+			return apperrors.MatchNotFound()
+		}
+		if match.WinnerEntryID != nil {
+			return apperrors.MatchAlreadyRecorded()
+		}
+		if match.HomeEntryID == nil || match.GuestEntryID == nil {
+			return apperrors.TournamentInvalidState()
+		}
+		if (match.Stage == models.TournamentStageGroup) != (tournament.Status == models.TournamentStatusGroupStage) {
+			return apperrors.TournamentInvalidState()
+		}
+
+		match.HomeSets, match.GuestSets = req.HomeSets, req.GuestSets
+		match.WinnerEntryID = match.GuestEntryID
+		if homeWins > guestWins {
+			match.WinnerEntryID = match.HomeEntryID
+		}
+
+		if err := s.rateTournamentMatch(ctx, tx, tournament, match, homeWins > guestWins); err != nil {
+			return err
+		}
+		if err := tx.Tournament.UpdateTournamentMatchRepo(ctx, match); err != nil {
+			return err
+		}
+
+		if match.Stage == models.TournamentStageGroup {
+			return startKnockoutIfGroupsDone(ctx, tx, tournament)
+		}
+		return advanceKnockoutWinner(ctx, tx, tournament, match)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+// rateTournamentMatch applies the rating deltas of a singles match to the
+// players' season points and logs them against the match
+func (s *tournamentService) rateTournamentMatch(ctx context.Context, tx *repository.Repository, tournament *models.Tournament, match *models.TournamentMatch, homeWon bool) error {
+	ranks, err := tx.Rank.GetAllRanksRepo(ctx)
+	if err != nil {
+		return err
+	}
+	kFactors := make(map[string]float64, len(ranks))
+	for _, rank := range ranks {
+		kFactors[rank.ID] = float64(rank.KFactor)
+	}
+
+	entries, err := tx.Tournament.GetEntriesRepo(ctx, tournament.ID)
+	if err != nil {
+		return err
+	}
+	playerSeasonIDs := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		playerSeasonIDs[entry.ID] = entry.PlayerSeasonID
+	}
+
+	pointBefore := make(map[string]float64, 2)
+	participant := func(entryID string) (rating.Participant, error) {
+		ps, err := tx.PlayerSeason.GetPlayerSeasonByIDRepo(ctx, playerSeasonIDs[entryID])
+		if err != nil {
+			return rating.Participant{}, err
+		}
+		if ps == nil {
+			return rating.Participant{}, apperrors.PlayerSeasonNotFound().WithDetails(map[string]string{"entry_id": entryID})
+		}
+
+		pointBefore[ps.ID] = ps.AccumulatedPoints
+		return rating.Participant{PlayerID: ps.ID, Rating: ps.AccumulatedPoints, KFactor: kFactors[ps.RankID]}, nil
+	}
+
+	home, err := participant(*match.HomeEntryID)
+	if err != nil {
+		return err
+	}
+	guest, err := participant(*match.GuestEntryID)
+	if err != nil {
+		return err
+	}
+
+	// Participants are keyed by player season id so the deltas map straight to the logs
+	deltas := s.rating.Deltas(rating.Outcome{
+		Home:    []rating.Participant{home},
+		Guest:   []rating.Participant{guest},
+		HomeWon: homeWon,
+	})
+
+	reason := fmt.Sprintf("Giải %s", tournament.Name)
+	pointAfter := make(map[string]float64, len(deltas))
+	for playerSeasonID, delta := range deltas {
+		total, err := tx.PlayerSeason.AddAccumulatedPointsRepo(ctx, playerSeasonID, delta)
+		if err != nil {
+			return err
+		}
+		pointAfter[playerSeasonID] = total
+
+		_, err = tx.PointLog.CreatePointLogRepo(ctx, &models.PointLog{
+			PlayerSeasonID: playerSeasonID,
+			DeltaPoints:    delta,
+			Reason:         &reason,
+			Source:         models.PointSourceTournament,
+			RefID:          &match.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if match.PointBefore, err = jsonString(pointBefore); err != nil {
+		return err
+	}
+	match.PointAfter, err = jsonString(pointAfter)
+	return err
+}
+
+// startKnockoutIfGroupsDone draws the knockout once every group match has a
+// result. Group winners take the top seeds, then the runners-up and so on.
+func startKnockoutIfGroupsDone(ctx context.Context, tx *repository.Repository, tournament *models.Tournament) error {
+	entries, err := tx.Tournament.GetEntriesRepo(ctx, tournament.ID)
+	if err != nil {
+		return err
+	}
+	matches, err := tx.Tournament.GetTournamentMatchesRepo(ctx, tournament.ID)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if match.WinnerEntryID == nil {
+			return nil
+		}
+	}
+
+	tables := groupTables(tournament.GroupCount, entries, matches)
+	var qualifiers []string
+	for place := 0; place < tournament.QualifiersPerGroup; place++ {
+		for _, table := range tables {
+			if place < len(table) {
+				qualifiers = append(qualifiers, table[place].EntryID)
+			}
+		}
+	}
+
+	size := bracketSize(len(qualifiers))
+	rounds := bracketRounds(size)
+	for round := 2; round <= rounds; round++ {
+		for position := 0; position < size>>round; position++ {
+			node := &models.TournamentMatch{
+				TournamentID: tournament.ID,
+				Stage:        models.TournamentStageKnockout,
+				Round:        round,
+				Position:     position,
+			}
+			if _, err := tx.Tournament.CreateTournamentMatchRepo(ctx, node); err != nil {
+				return err
+			}
+		}
+	}
+
+	tournament.Status = models.TournamentStatusKnockout
+	if err := tx.Tournament.UpdateTournamentStatusRepo(ctx, tournament.ID, tournament.Status); err != nil {
+		return err
+	}
+
+	order := seedOrder(size)
+	for position := 0; position < size/2; position++ {
+		node := &models.TournamentMatch{
+			TournamentID: tournament.ID,
+			Stage:        models.TournamentStageKnockout,
+			Round:        1,
+			Position:     position,
+		}
+		// Seeds beyond the qualifiers are byes and win straight away
+		if seed := order[2*position]; seed <= len(qualifiers) {
+			node.HomeEntryID = &qualifiers[seed-1]
+		}
+		if seed := order[2*position+1]; seed <= len(qualifiers) {
+			node.GuestEntryID = &qualifiers[seed-1]
+		}
+		if node.HomeEntryID == nil || node.GuestEntryID == nil {
+			node.WinnerEntryID = node.HomeEntryID
+			if node.WinnerEntryID == nil {
+				node.WinnerEntryID = node.GuestEntryID
+			}
+		}
+
+		if _, err := tx.Tournament.CreateTournamentMatchRepo(ctx, node); err != nil {
+			return err
+		}
+		if node.WinnerEntryID != nil {
+			if err := advanceKnockoutWinner(ctx, tx, tournament, node); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// advanceKnockoutWinner moves the winner of a knockout match to the next
+// round; the winner of the final completes the tournament
+func advanceKnockoutWinner(ctx context.Context, tx *repository.Repository, tournament *models.Tournament, match *models.TournamentMatch) error {
+	next, err := tx.Tournament.LockKnockoutMatchRepo(ctx, tournament.ID, match.Round+1, match.Position/2)
+	if err != nil {
+		return err
+	}
+	if next == nil {
+		tournament.Status = models.TournamentStatusCompleted
+		return tx.Tournament.UpdateTournamentStatusRepo(ctx, tournament.ID, tournament.Status)
+	}
+
+	if match.Position%2 == 0 {
+		next.HomeEntryID = match.WinnerEntryID
+	} else {
+		next.GuestEntryID = match.WinnerEntryID
+	}
+	return tx.Tournament.UpdateTournamentMatchRepo(ctx, next)
+}
+
+// groupTables ranks the entries of every group by wins, then set and point
+// difference, then seed
+func groupTables(groupCount int, entries []models.TournamentEntry, matches []models.TournamentMatch) [][]models.TournamentGroupRow {
+	rows := make(map[string]*models.TournamentGroupRow, len(entries))
+	seeds := make(map[string]int, len(entries))
+	tables := make([][]models.TournamentGroupRow, groupCount)
+	for _, entry := range entries {
+		if entry.GroupNumber == nil || *entry.GroupNumber < 1 || *entry.GroupNumber > groupCount {
+			continue
+		}
+		if entry.Seed != nil {
+			seeds[entry.ID] = *entry.Seed
+		}
+		rows[entry.ID] = &models.TournamentGroupRow{EntryID: entry.ID, PlayerID: entry.PlayerID, PlayerName: entry.PlayerName}
+	}
+
+	for _, match := range matches {
+		if match.Stage != models.TournamentStageGroup || match.WinnerEntryID == nil {
+			continue
+		}
+		home, guest := rows[*match.HomeEntryID], rows[*match.GuestEntryID]
+		if home == nil || guest == nil {
+			continue
+		}
+
+		homeWon := *match.WinnerEntryID == *match.HomeEntryID
+		addGroupResult(home, match.HomeSets, match.GuestSets, homeWon)
+		addGroupResult(guest, match.GuestSets, match.HomeSets, !homeWon)
+	}
+
+	for _, entry := range entries {
+		if row := rows[entry.ID]; row != nil {
+			tables[*entry.GroupNumber-1] = append(tables[*entry.GroupNumber-1], *row)
+		}
+	}
+
+	for _, table := range tables {
+		sort.SliceStable(table, func(i, j int) bool {
+			a, b := table[i], table[j]
+			if a.Wins != b.Wins {
+				return a.Wins > b.Wins
+			}
+			if d1, d2 := a.SetsWon-a.SetsLost, b.SetsWon-b.SetsLost; d1 != d2 {
+				return d1 > d2
+			}
+			if d1, d2 := a.PointsWon-a.PointsLost, b.PointsWon-b.PointsLost; d1 != d2 {
+				return d1 > d2
+			}
+			return seeds[a.EntryID] < seeds[b.EntryID]
+		})
+	}
+
+	return tables
+}
+
+func addGroupResult(row *models.TournamentGroupRow, setsFor, setsAgainst []int64, won bool) {
+	row.Played++
+	if won {
+		row.Wins++
+	} else {
+		row.Losses++
+	}
+
+	for i := range setsFor {
+		if i >= len(setsAgainst) {
+			break
+		}
+		if setsFor[i] > setsAgainst[i] {
+			row.SetsWon++
+		} else {
+			row.SetsLost++
+		}
+		row.PointsWon += int(setsFor[i])
+		row.PointsLost += int(setsAgainst[i])
+	}
+}
+
+func lockTournament(ctx context.Context, tx *repository.Repository, id, status string) (*models.Tournament, error) {
+	tournament, err := tx.Tournament.LockTournamentRepo(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tournament == nil {
+		return nil, apperrors.TournamentNotFound()
+	}
+	if tournament.Status != status {
+		return nil, apperrors.TournamentInvalidState()
+	}
+	return tournament, nil
+}
+
+func buildTournamentDetail(ctx context.Context, repo *repository.Repository, tournament *models.Tournament) (*models.TournamentDetail, error) {
+	entries, err := repo.Tournament.GetEntriesRepo(ctx, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := repo.Tournament.GetTournamentMatchesRepo(ctx, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.TournamentDetail{
+		Tournament: *tournament,
+		Entries:    entries,
+		Groups:     []models.TournamentGroup{},
+		Knockout:   []models.TournamentMatch{},
+	}
+	if detail.Entries == nil {
+		detail.Entries = []models.TournamentEntry{}
+	}
+
+	if tournament.Status == models.TournamentStatusRegistration {
+		return detail, nil
+	}
+
+	tables := groupTables(tournament.GroupCount, entries, matches)
+	for i, table := range tables {
+		detail.Groups = append(detail.Groups, models.TournamentGroup{
+			GroupNumber: i + 1,
+			Table:       table,
+			Matches:     []models.TournamentMatch{},
+		})
+	}
+
+	for _, match := range matches {
+		if match.Stage == models.TournamentStageKnockout {
+			detail.Knockout = append(detail.Knockout, match)
+			continue
+		}
+		if match.GroupNumber != nil && *match.GroupNumber >= 1 && *match.GroupNumber <= len(detail.Groups) {
+			group := &detail.Groups[*match.GroupNumber-1]
+			group.Matches = append(group.Matches, match)
+		}
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type TransferService interface {
+	TransferPlayerService(ctx context.Context, fromTeamID string, req models.TransferRequest) (*models.Transfer, error)
+}
+
+type transferService struct {
+	store *repository.Repository
+}
+
+func NewTransferService(store *repository.Repository) TransferService {
+	return &transferService{store: store}
+}
+
+// TransferPlayerService moves a player to another team of the same season.
+// The old membership is closed at req.LastRound and the new one opens at the
+// following round, which must not be finalized yet.
+func (s *transferService) TransferPlayerService(ctx context.Context, fromTeamID string, req models.TransferRequest) (*models.Transfer, error) {
+	if req.LastRound <= 0 {
+		return nil, apperrors.InvalidInput("last_round phải lớn hơn 0")
+	}
+	if fromTeamID == req.ToTeamID {
+		return nil, apperrors.InvalidInput("Đội chuyển đến phải khác đội hiện tại")
+	}
+
+	var transfer *models.Transfer
+	err := s.store.WithTx(ctx, func(tx *repository.Repository) error {
+		fromTeam, err := tx.Team.GetTeamByIDRepo(ctx, fromTeamID)
+		if err != nil {
+			return err
+		}
+		if fromTeam == nil {
+			return apperrors.TeamNotFound()
+		}
+		seasonID := fromTeam.SeasonID
+
+		toTeam, err := tx.Team.GetTeamByIDRepo(ctx, req.ToTeamID)
+		if err != nil {
+			return err
+		}
+		if toTeam == nil || toTeam.SeasonID != seasonID {
+			return apperrors.TeamNotFound().WithDetails(map[string]string{"team_id": req.ToTeamID})
+		}
+
+		ps, err := tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, seasonID, req.PlayerID)
+		if err != nil {
+			return err
+		}
+		if ps == nil {
+			return apperrors.PlayerSeasonNotFound()
+		}
+
+		// Concurrent transfers of the player queue on the registration row, so
+		// the team and transfer count below include the ones committed before
+		ps, err = tx.PlayerSeason.LockPlayerSeasonRepo(ctx, ps.ID)
+		if err != nil {
+			return err
+		}
+		if ps == nil {
+			return apperrors.PlayerSeasonNotFound()
+		}
+		if ps.TeamID != fromTeamID {
+			return apperrors.InvalidInput("VĐV không thuộc đội này")
+		}
+
+		settings, err := loadSeasonSettings(ctx, tx.Settings, seasonID)
+		if err != nil {
+			return err
+		}
+		if !inTransferWindow(settings, req.LastRound) {
+			return apperrors.TransferWindowClosed()
+		}
+
+		transfers, err := tx.Team.CountTransfersRepo(ctx, seasonID, req.PlayerID)
+		if err != nil {
+			return err
+		}
+		if transfers >= settings.MaxTransfersPerPlayer {
+			return apperrors.TransferLimitReached()
+		}
+
+		firstRound := req.LastRound + 1
+		if err := ensureRoundOpen(ctx, tx, seasonID, firstRound); err != nil {
+			return err
+		}
+
+		closed, err := s.closeMembership(ctx, tx, seasonID, ps, req.LastRound)
+		if err != nil {
+			return err
+		}
+
+		opened := models.TeamMember{
+			TeamID:       req.ToTeamID,
+			PlayerID:     req.PlayerID,
+			JoinedRound:  firstRound,
+			TransferType: models.TransferTypeTransfer,
+		}
+		if err := tx.Team.CreateTeamMemberRepo(ctx, seasonID, &opened); err != nil {
+			return err
+		}
+
+		if err := tx.PlayerSeason.UpdatePlayerSeasonTeamRepo(ctx, ps.ID, req.ToTeamID); err != nil {
+			return err
+		}
+
+		transfer = &models.Transfer{
+			PlayerSeasonID: ps.ID,
+			PlayerID:       req.PlayerID,
+			FromTeamID:     fromTeamID,
+			ToTeamID:       req.ToTeamID,
+			Closed:         *closed,
+			Opened:         opened,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// closeMembership ends the player's open membership at lastRound. Players
+// registered before memberships were tracked get an INITIAL row from round 1.
+func (s *transferService) closeMembership(ctx context.Context, tx *repository.Repository, seasonID string, ps *models.PlayerSeason, lastRound int) (*models.TeamMember, error) {
+	member, err := tx.Team.GetOpenMembershipRepo(ctx, seasonID, ps.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member == nil {
+		member = &models.TeamMember{
+			TeamID:       ps.TeamID,
+			PlayerID:     ps.PlayerID,
+			JoinedRound:  1,
+			LeftRound:    &lastRound,
+			TransferType: models.TransferTypeInitial,
+		}
+		return member, tx.Team.CreateTeamMemberRepo(ctx, seasonID, member)
+	}
+
+	if member.JoinedRound > lastRound {
+		return nil, apperrors.InvalidInput("last_round phải sau vòng VĐV gia nhập đội")
+	}
+
+	member.LeftRound = &lastRound
+	return member, tx.Team.CloseTeamMemberRepo(ctx, seasonID, member)
+}
+
+func inTransferWindow(settings *models.SeasonSettings, round int) bool {
+	if settings.TransferWindowStartRound != nil && round < *settings.TransferWindowStartRound {
+		return false
+	}
+	if settings.TransferWindowEndRound != nil && round > *settings.TransferWindowEndRound {
+		return false
+	}
+	return true
+}
+
+// playerTeamAtRound returns the team a player belonged to in a round. Players
+// without any membership row fall back to their current season team.
+func playerTeamAtRound(ctx context.Context, tx *repository.Repository, seasonID, playerID string, round int) (string, error) {
+	teamID, err := tx.Team.GetPlayerTeamAtRoundRepo(ctx, seasonID, playerID, round)
+	if err != nil || teamID != "" {
+		return teamID, err
+	}
+
+	member, err := tx.Team.GetOpenMembershipRepo(ctx, seasonID, playerID)
+	if err != nil || member != nil {
+		// The player has memberships, none of which covers this round
+		return "", err
+	}
+
+	ps, err := tx.PlayerSeason.GetPlayerSeasonByPlayerRepo(ctx, seasonID, playerID)
+	if err != nil || ps == nil {
+		return "", err
+	}
+	return ps.TeamID, nil
+}
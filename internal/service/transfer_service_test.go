@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperrors "backend-ping-pong-app/internal/errors"
+	"backend-ping-pong-app/internal/models"
+	"backend-ping-pong-app/internal/repository"
+)
+
+type transferTeamRepo struct {
+	repository.TeamRepository
+	teams  map[string]*models.Team
+	counts int
+}
+
+func (r *transferTeamRepo) GetTeamByIDRepo(ctx context.Context, id string) (*models.Team, error) {
+	return r.teams[id], nil
+}
+
+func (r *transferTeamRepo) CountTransfersRepo(ctx context.Context, seasonID, playerID string) (int, error) {
+	r.counts++
+	return 0, nil
+}
+
+// racedPlayerSeasonRepo reads the registration before a concurrent transfer
+// commits and locks it after
+type racedPlayerSeasonRepo struct {
+	repository.PlayerSeasonRepository
+	before, after *models.PlayerSeason
+	locked        bool
+}
+
+func (r *racedPlayerSeasonRepo) GetPlayerSeasonByPlayerRepo(ctx context.Context, seasonID, playerID string) (*models.PlayerSeason, error) {
+	return r.before, nil
+}
+
+func (r *racedPlayerSeasonRepo) LockPlayerSeasonRepo(ctx context.Context, id string) (*models.PlayerSeason, error) {
+	r.locked = true
+	return r.after, nil
+}
+
+func TestTransferPlayerRechecksTeamUnderLock(t *testing.T) {
+	teams := &transferTeamRepo{teams: map[string]*models.Team{
+		"from":  {ID: "from", SeasonID: "season"},
+		"to":    {ID: "to", SeasonID: "season"},
+		"other": {ID: "other", SeasonID: "season"},
+	}}
+	playerSeasons := &racedPlayerSeasonRepo{
+		before: &models.PlayerSeason{ID: "ps", SeasonID: "season", PlayerID: "player", TeamID: "from"},
+		after:  &models.PlayerSeason{ID: "ps", SeasonID: "season", PlayerID: "player", TeamID: "other"},
+	}
+	transfers := NewTransferService(&repository.Repository{Team: teams, PlayerSeason: playerSeasons})
+
+	_, err := transfers.TransferPlayerService(context.Background(), "from", models.TransferRequest{
+		PlayerID:  "player",
+		ToTeamID:  "to",
+		LastRound: 3,
+	})
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrorInvalidInput {
+		t.Fatalf("TransferPlayerService error = %v, want %s", err, apperrors.ErrorInvalidInput)
+	}
+	if !playerSeasons.locked {
+		t.Error("registration was not locked")
+	}
+	if teams.counts != 0 {
+		t.Errorf("transfers counted %d times for a player who already left the team", teams.counts)
+	}
+}
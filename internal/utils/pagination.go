@@ -0,0 +1,31 @@
+package utils
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Pagination is a normalized page request (pages start at 1)
+type Pagination struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+}
+
+// NewPagination clamps page and pageSize to valid values
+func NewPagination(page, pageSize int) Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return Pagination{Page: page, PageSize: pageSize}
+}
+
+// Offset returns the number of rows to skip for the page
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
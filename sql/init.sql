@@ -9,22 +9,42 @@ CREATE TABLE IF NOT EXISTS ranks (
   min_score INT,
   max_score INT,
   standard_score INT NOT NULL,
+  k_factor INT NOT NULL DEFAULT 32, -- ELO K-factor, higher for lower ranks
   description TEXT,
   created_at TIMESTAMP DEFAULT now()
 );
 
+-- Databases created before k_factor get the column with the K-factor of
+-- every seeded rank; it is set only once so later edits are kept
+DO $$
+BEGIN
+  IF NOT EXISTS (
+    SELECT 1 FROM information_schema.columns
+    WHERE table_schema = current_schema() AND table_name = 'ranks' AND column_name = 'k_factor'
+  ) THEN
+    ALTER TABLE ranks ADD COLUMN IF NOT EXISTS k_factor INT NOT NULL DEFAULT 32;
+    UPDATE ranks r SET k_factor = v.k_factor
+    FROM (VALUES
+      ('C3', 40), ('C2', 36), ('C1', 32),
+      ('B3', 28), ('B2', 24), ('B1', 24),
+      ('A3', 20), ('A2', 16), ('A1', 16)
+    ) AS v(id, k_factor)
+    WHERE r.id = v.id;
+  END IF;
+END $$;
+
 -- Seed initial ranks data
-INSERT INTO ranks (id, sort_order, min_score, max_score, standard_score, description)
+INSERT INTO ranks (id, sort_order, min_score, max_score, standard_score, k_factor, description)
 VALUES 
-  ('C3', 1, NULL, 499, 50, 'Hạng C3 - Người mới'),
-  ('C2', 2, 500, 799, 60, 'Hạng C2'),
-  ('C1', 3, 800, 1099, 70, 'Hạng C1'),
-  ('B3', 4, 1100, 1399, 80, 'Hạng B3'),
-  ('B2', 5, 1400, 1699, 90, 'Hạng B2'),
-  ('B1', 6, 1700, 1999, 100, 'Hạng B1'),
-  ('A3', 7, 2000, 2499, 110, 'Hạng A3'),
-  ('A2', 8, 2500, 2999, 120, 'Hạng A2'),
-  ('A1', 9, 3000, NULL, 130, 'Hạng A1 - Xuất sắc')
+  ('C3', 1, NULL, 499, 50, 40, 'Hạng C3 - Người mới'),
+  ('C2', 2, 500, 799, 60, 36, 'Hạng C2'),
+  ('C1', 3, 800, 1099, 70, 32, 'Hạng C1'),
+  ('B3', 4, 1100, 1399, 80, 28, 'Hạng B3'),
+  ('B2', 5, 1400, 1699, 90, 24, 'Hạng B2'),
+  ('B1', 6, 1700, 1999, 100, 24, 'Hạng B1'),
+  ('A3', 7, 2000, 2499, 110, 20, 'Hạng A3'),
+  ('A2', 8, 2500, 2999, 120, 16, 'Hạng A2'),
+  ('A1', 9, 3000, NULL, 130, 16, 'Hạng A1 - Xuất sắc')
 ON CONFLICT (id) DO NOTHING;
 
 -- ==================== Teams Table ====================
@@ -44,10 +64,14 @@ CREATE TABLE IF NOT EXISTS players (
   phone TEXT,
   cccd TEXT,
   avatar_url TEXT,
+  firebase_uid TEXT UNIQUE, -- mobile app account, NULL until linked
   is_active BOOLEAN DEFAULT true,
   created_at TIMESTAMP DEFAULT now()
 );
 
+-- Columns added after the table was first created
+ALTER TABLE players ADD COLUMN IF NOT EXISTS firebase_uid TEXT UNIQUE;
+
 -- ==================== Seasons Table ====================
 CREATE TABLE IF NOT EXISTS seasons (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -57,6 +81,27 @@ CREATE TABLE IF NOT EXISTS seasons (
   created_at TIMESTAMP DEFAULT now()
 );
 
+-- ==================== Season Settings Table ====================
+-- Per-season rule configuration, defaults apply when no row exists
+CREATE TABLE IF NOT EXISTS season_settings (
+  season_id UUID PRIMARY KEY REFERENCES seasons(id) ON DELETE CASCADE,
+  promotion_buffer INT NOT NULL DEFAULT 25,
+  relegation_buffer INT NOT NULL DEFAULT 25,
+  transfer_window_start_round INT, -- NULL: no lower bound
+  transfer_window_end_round INT,   -- NULL: no upper bound
+  max_transfers_per_player INT NOT NULL DEFAULT 1,
+  points_per_win INT NOT NULL DEFAULT 3,
+  points_per_draw INT NOT NULL DEFAULT 1,
+  points_per_loss INT NOT NULL DEFAULT 0,
+  handicap_enabled BOOLEAN NOT NULL DEFAULT true,
+  handicap_step INT NOT NULL DEFAULT 10,      -- standard score difference per starting point
+  handicap_max_points INT NOT NULL DEFAULT 7,
+  max_appearances_per_fixture INT NOT NULL DEFAULT 3, -- rubbers per player in a fixture lineup
+  no_show_penalty_points INT NOT NULL DEFAULT 20,     -- deducted for an unjustified walkover
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now()
+);
+
 -- ==================== Player Seasons Table ====================
 -- This is the core table storing player data during a season
 CREATE TABLE IF NOT EXISTS player_seasons (
@@ -78,6 +123,22 @@ CREATE INDEX IF NOT EXISTS idx_player_seasons_season_id ON player_seasons(season
 CREATE INDEX IF NOT EXISTS idx_player_seasons_player_id ON player_seasons(player_id);
 CREATE INDEX IF NOT EXISTS idx_player_seasons_accumulated_points ON player_seasons(accumulated_points DESC);
 
+-- ==================== Team Members Table ====================
+-- Round-bounded team membership, a transfer closes one row and opens another
+CREATE TABLE IF NOT EXISTS team_members (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+  joined_round INT NOT NULL CHECK (joined_round > 0),
+  left_round INT CHECK (left_round IS NULL OR left_round >= joined_round),
+  transfer_type TEXT NOT NULL DEFAULT 'INITIAL', -- INITIAL, TRANSFER
+  created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_team_id ON team_members(team_id);
+CREATE INDEX IF NOT EXISTS idx_team_members_season_player ON team_members(season_id, player_id);
+
 -- ==================== Player Point Logs Table ====================
 -- Audit trail for all point changes
 CREATE TABLE IF NOT EXISTS player_point_logs (
@@ -85,7 +146,7 @@ CREATE TABLE IF NOT EXISTS player_point_logs (
   player_season_id UUID NOT NULL REFERENCES player_seasons(id) ON DELETE CASCADE,
   delta_points NUMERIC(10,2) NOT NULL,
   reason TEXT,
-  source TEXT DEFAULT 'MATCH', -- MATCH, ADMIN_ADJUST, PENALTY, BONUS
+  source TEXT DEFAULT 'MATCH', -- MATCH, TOURNAMENT, ADMIN_ADJUST, PENALTY, BONUS
   ref_id UUID,
   created_at TIMESTAMP DEFAULT now()
 );
@@ -103,11 +164,15 @@ CREATE TABLE IF NOT EXISTS fixtures (
   guest_team_id UUID NOT NULL REFERENCES teams(id),
   home_score INT DEFAULT 0,
   guest_score INT DEFAULT 0,
-  status TEXT DEFAULT 'SCHEDULED', -- SCHEDULED, ONGOING, COMPLETED
+  status TEXT DEFAULT 'SCHEDULED', -- SCHEDULED, ONGOING, COMPLETED, CANCELLED
+  lineup_deadline TIMESTAMP,       -- NULL: lineups are locked manually
   created_at TIMESTAMP DEFAULT now(),
   updated_at TIMESTAMP DEFAULT now()
 );
 
+-- Columns added after the table was first created
+ALTER TABLE fixtures ADD COLUMN IF NOT EXISTS lineup_deadline TIMESTAMP;
+
 -- Create index
 CREATE INDEX IF NOT EXISTS idx_fixtures_season_id ON fixtures(season_id);
 CREATE INDEX IF NOT EXISTS idx_fixtures_round ON fixtures(round);
@@ -129,15 +194,236 @@ CREATE TABLE IF NOT EXISTS matches (
   home_sets INT[],
   guest_sets INT[],
   winner_team_id UUID REFERENCES teams(id),
+  result_type TEXT NOT NULL DEFAULT 'NORMAL', -- NORMAL, WALKOVER, RETIRED, DISQUALIFIED
   created_at TIMESTAMP DEFAULT now(),
   updated_at TIMESTAMP DEFAULT now()
 );
 
+-- Columns added after the table was first created
+ALTER TABLE matches ADD COLUMN IF NOT EXISTS result_type TEXT NOT NULL DEFAULT 'NORMAL';
+
 -- Create index
 CREATE INDEX IF NOT EXISTS idx_matches_fixture_id ON matches(fixture_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_matches_fixture_order ON matches(fixture_id, match_order);
 CREATE INDEX IF NOT EXISTS idx_matches_winner ON matches(winner_team_id);
 CREATE INDEX IF NOT EXISTS idx_matches_created_at ON matches(created_at DESC);
 
+-- ==================== Match Amendments Table ====================
+-- Audit of corrections made to recorded rubbers, with the result before and after
+CREATE TABLE IF NOT EXISTS match_amendments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  old_home_sets INT[],
+  old_guest_sets INT[],
+  old_winner_team_id UUID REFERENCES teams(id),
+  old_result_type TEXT NOT NULL,
+  new_home_sets INT[],
+  new_guest_sets INT[],
+  new_winner_team_id UUID REFERENCES teams(id),
+  new_result_type TEXT NOT NULL,
+  created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_match_amendments_match_id ON match_amendments(match_id, created_at DESC);
+
+-- ==================== Lineups Table ====================
+-- Ordered lineup a team submits for a fixture. Lineups lock at the fixture's
+-- lineup deadline and, once both teams are locked, pre-fill the matches rows.
+CREATE TABLE IF NOT EXISTS lineups (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  fixture_id UUID NOT NULL REFERENCES fixtures(id) ON DELETE CASCADE,
+  team_id UUID NOT NULL REFERENCES teams(id),
+  status TEXT DEFAULT 'SUBMITTED', -- SUBMITTED, LOCKED
+  submitted_at TIMESTAMP DEFAULT now(),
+  locked_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now(),
+  UNIQUE (fixture_id, team_id)
+);
+
+CREATE TABLE IF NOT EXISTS lineup_entries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  lineup_id UUID NOT NULL REFERENCES lineups(id) ON DELETE CASCADE,
+  match_order INT NOT NULL,
+  match_type TEXT NOT NULL, -- SINGLE, DOUBLE
+  player1_id UUID NOT NULL REFERENCES players(id),
+  player2_id UUID REFERENCES players(id),
+  UNIQUE (lineup_id, match_order)
+);
+
+-- ==================== Brackets Table ====================
+-- Single elimination playoff between the teams of a season
+CREATE TABLE IF NOT EXISTS brackets (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  size INT NOT NULL,             -- power of two, entries below it get byes
+  entries INT NOT NULL,
+  seed_source TEXT NOT NULL,     -- STANDINGS, LEADERBOARD
+  third_place BOOLEAN NOT NULL DEFAULT false,
+  start_round INT NOT NULL,      -- fixture round of the first bracket round
+  status TEXT DEFAULT 'ACTIVE',  -- ACTIVE, COMPLETED
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_brackets_season_id ON brackets(season_id);
+
+-- One node of the bracket tree. The winner of (round, position) moves to
+-- (round + 1, position / 2); the third place match sits beside the final.
+CREATE TABLE IF NOT EXISTS bracket_matches (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  bracket_id UUID NOT NULL REFERENCES brackets(id) ON DELETE CASCADE,
+  round INT NOT NULL,
+  position INT NOT NULL,
+  third_place BOOLEAN NOT NULL DEFAULT false,
+  home_seed INT,
+  guest_seed INT,
+  home_team_id UUID REFERENCES teams(id),
+  guest_team_id UUID REFERENCES teams(id),
+  fixture_id UUID REFERENCES fixtures(id) ON DELETE SET NULL,
+  winner_team_id UUID REFERENCES teams(id),
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now(),
+  UNIQUE (bracket_id, round, position, third_place)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bracket_matches_fixture ON bracket_matches(fixture_id);
+
+-- ==================== Tournaments Table ====================
+-- Individual singles cup: round-robin groups followed by a knockout
+CREATE TABLE IF NOT EXISTS tournaments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  group_count INT NOT NULL CHECK (group_count > 0),
+  qualifiers_per_group INT NOT NULL CHECK (qualifiers_per_group > 0),
+  status TEXT DEFAULT 'REGISTRATION', -- REGISTRATION, GROUP_STAGE, KNOCKOUT, COMPLETED
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournaments_season_id ON tournaments(season_id);
+
+CREATE TABLE IF NOT EXISTS tournament_entries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tournament_id UUID NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+  player_id UUID NOT NULL REFERENCES players(id),
+  player_season_id UUID NOT NULL REFERENCES player_seasons(id) ON DELETE CASCADE,
+  team_id UUID REFERENCES teams(id),
+  seed INT,
+  group_number INT,
+  created_at TIMESTAMP DEFAULT now(),
+  UNIQUE (tournament_id, player_id)
+);
+
+-- Group matches have a group number; knockout nodes follow the bracket layout
+-- where the winner of (round, position) moves to (round + 1, position / 2)
+CREATE TABLE IF NOT EXISTS tournament_matches (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  tournament_id UUID NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+  stage TEXT NOT NULL, -- GROUP, KNOCKOUT
+  group_number INT,
+  round INT NOT NULL,
+  position INT NOT NULL,
+  home_entry_id UUID REFERENCES tournament_entries(id),
+  guest_entry_id UUID REFERENCES tournament_entries(id),
+  home_sets INT[],
+  guest_sets INT[],
+  winner_entry_id UUID REFERENCES tournament_entries(id),
+  point_before TEXT, -- JSON: points before match
+  point_after TEXT,  -- JSON: points after match
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tournament_matches_tournament ON tournament_matches(tournament_id, stage, round);
+
+-- ==================== Rounds Table ====================
+-- Round lifecycle: OPEN -> LOCKED -> FINALIZED (finalized only once)
+CREATE TABLE IF NOT EXISTS rounds (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  round_number INT NOT NULL CHECK (round_number > 0),
+  status TEXT DEFAULT 'OPEN', -- OPEN, LOCKED, FINALIZED
+  finalized_at TIMESTAMP,
+  created_at TIMESTAMP DEFAULT now(),
+  updated_at TIMESTAMP DEFAULT now(),
+  UNIQUE (season_id, round_number)
+);
+
+-- ==================== Player Round Points Table ====================
+-- Points earned by each player in a finalized round
+CREATE TABLE IF NOT EXISTS player_round_points (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_season_id UUID NOT NULL REFERENCES player_seasons(id) ON DELETE CASCADE,
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  round_number INT NOT NULL,
+  points_earned NUMERIC(10,2) NOT NULL DEFAULT 0,
+  created_at TIMESTAMP DEFAULT now(),
+  UNIQUE (player_season_id, round_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_round_points_season_round ON player_round_points(season_id, round_number);
+
+-- ==================== Player Round Standings Table ====================
+-- Leaderboard snapshot taken after each finalized round
+CREATE TABLE IF NOT EXISTS player_round_standings (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_season_id UUID NOT NULL REFERENCES player_seasons(id) ON DELETE CASCADE,
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  round_number INT NOT NULL,
+  team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+  rank_id VARCHAR(10) REFERENCES ranks(id),
+  accumulated_points NUMERIC(10,2) NOT NULL,
+  rank_position INT NOT NULL,
+  created_at TIMESTAMP DEFAULT now(),
+  UNIQUE (player_season_id, round_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_round_standings_season_round ON player_round_standings(season_id, round_number, rank_position);
+
+-- ==================== Team Standings Table ====================
+-- Team table recomputed from completed fixtures
+CREATE TABLE IF NOT EXISTS team_standings (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  played INT NOT NULL DEFAULT 0,
+  wins INT NOT NULL DEFAULT 0,
+  draws INT NOT NULL DEFAULT 0,
+  losses INT NOT NULL DEFAULT 0,
+  rubbers_won INT NOT NULL DEFAULT 0,
+  rubbers_lost INT NOT NULL DEFAULT 0,
+  sets_won INT NOT NULL DEFAULT 0,
+  sets_lost INT NOT NULL DEFAULT 0,
+  points_won INT NOT NULL DEFAULT 0,
+  points_lost INT NOT NULL DEFAULT 0,
+  total_points INT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP DEFAULT now(),
+  UNIQUE (season_id, team_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_standings_season_position ON team_standings(season_id, position);
+
+-- ==================== Player Rank History Table ====================
+-- Audit of promotions and relegations
+CREATE TABLE IF NOT EXISTS player_rank_history (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  player_season_id UUID NOT NULL REFERENCES player_seasons(id) ON DELETE CASCADE,
+  player_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+  season_id UUID NOT NULL REFERENCES seasons(id) ON DELETE CASCADE,
+  round_number INT NOT NULL DEFAULT 0, -- 0 when changed outside round finalization
+  old_rank_id VARCHAR(10) NOT NULL REFERENCES ranks(id),
+  new_rank_id VARCHAR(10) NOT NULL REFERENCES ranks(id),
+  points NUMERIC(10,2) NOT NULL,
+  created_at TIMESTAMP DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rank_history_season_player ON player_rank_history(season_id, player_id);
+
 -- ==================== Staging Players Table ====================
 -- For importing raw data from Excel/CSV
 CREATE TABLE IF NOT EXISTS staging_players (
@@ -159,12 +445,52 @@ CREATE TABLE IF NOT EXISTS admins (
   id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
   email TEXT NOT NULL UNIQUE,
   password_hash TEXT NOT NULL,
-  role TEXT DEFAULT 'ADMIN', -- ADMIN, MODERATOR, VIEWER
+  role TEXT DEFAULT 'ADMIN', -- ADMIN, MODERATOR, CAPTAIN, VIEWER
   is_active BOOLEAN DEFAULT true,
+  must_change_password BOOLEAN NOT NULL DEFAULT false, -- set for invited and reset accounts
+  password_changed_at TIMESTAMP NOT NULL DEFAULT now(), -- tokens issued under an older password are rejected
   created_at TIMESTAMP DEFAULT now(),
   updated_at TIMESTAMP DEFAULT now()
 );
 
+-- Columns added after the table was first created
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS must_change_password BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP NOT NULL DEFAULT now();
+
+-- ==================== Team Captains Table ====================
+-- CAPTAIN accounts allowed to submit the lineups of a team
+CREATE TABLE IF NOT EXISTS team_captains (
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  admin_id UUID NOT NULL REFERENCES admins(id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT now(),
+  PRIMARY KEY (team_id, admin_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_captains_admin_id ON team_captains(admin_id);
+
+-- ==================== API Keys Table ====================
+-- Scoped keys of third-party integrations, only the SHA-256 of a key is kept
+CREATE TABLE IF NOT EXISTS api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  prefix TEXT NOT NULL,            -- first characters of the key, to tell keys apart
+  key_hash TEXT NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,          -- leaderboard:read, fixtures:read, results:write
+  created_by UUID REFERENCES admins(id) ON DELETE SET NULL,
+  usage_count BIGINT NOT NULL DEFAULT 0,
+  last_used_at TIMESTAMP,
+  revoked_at TIMESTAMP,            -- NULL: active
+  created_at TIMESTAMP DEFAULT now()
+);
+
+-- Requests per key and day, for auditing
+CREATE TABLE IF NOT EXISTS api_key_usage (
+  api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+  usage_date DATE NOT NULL,
+  request_count BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (api_key_id, usage_date)
+);
+
 -- ==================== Useful Views ====================
 
 -- View for getting top scorers
@@ -242,6 +568,10 @@ WHERE ps.status = 'ACTIVE';
 CREATE INDEX IF NOT EXISTS idx_mat_leaderboard_season_id 
   ON mat_season_leaderboard(season_id, rank);
 
+-- REFRESH MATERIALIZED VIEW CONCURRENTLY requires a unique index
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mat_leaderboard_player_season
+  ON mat_season_leaderboard(player_season_id);
+
 -- ==================== Grant Refresh Permission ====================
 -- Allow background jobs to refresh the materialized view
 -- GRANT REFRESH ON MATERIALIZED VIEW mat_season_leaderboard TO postgres;